@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"github.com/Rom1-J/preprocessor/app/extract/logic"
+	"github.com/Rom1-J/preprocessor/app/extract/logic/generator"
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/mmdb"
+	"github.com/Rom1-J/preprocessor/pkg/noise"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
-	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
 	ucli "github.com/urfave/cli/v3"
-	"google.golang.org/protobuf/proto"
 	"os"
 	"path/filepath"
-	"sync"
+	"regexp"
+	"strings"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -34,6 +39,7 @@ func Action(ctx context.Context, command *ucli.Command) error {
 	inputDirectories := command.StringSlice("directory")
 	searchRecursively := command.Bool("recursive")
 	overwrite := command.Bool("overwrite")
+	incremental := command.Bool("incremental")
 
 	for _, directory := range inputDirectories {
 		if err = filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
@@ -46,9 +52,9 @@ func Action(ctx context.Context, command *ucli.Command) error {
 				if _, err := os.Stat(infoFilePath); err == nil {
 					metadataFilePath := filepath.Join(path, "_metadata.pb")
 
-					if _, err := os.Stat(metadataFilePath); err == nil && !overwrite {
+					if _, err := os.Stat(metadataFilePath); err == nil && !overwrite && !incremental {
 						var msg = fmt.Sprintf(
-							"Skipping directory '%s', use --overwrite to ignore existing _metadata.pb",
+							"Skipping directory '%s', use --overwrite or --incremental to ignore existing _metadata.pb",
 							path,
 						)
 						logger.Logger.Info().Msgf(msg)
@@ -71,6 +77,108 @@ func Action(ctx context.Context, command *ucli.Command) error {
 	logger.Logger.Debug().Msgf("Input directories: %v", inputList)
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Retrieving extract options
+	//
+	extractOpts := structs.ExtractOptsStruct{
+		HmacKey:          []byte(command.String("hmac-key")),
+		PasswordMode:     strings.ToLower(command.String("password-mode")),
+		PrivacyMode:      strings.ToLower(command.String("privacy-mode")),
+		RecordMode:       strings.ToLower(command.String("records")),
+		ColumnDictionary: constants.ColumnDictionary,
+	}
+
+	if path := command.String("column-dictionary"); path != "" {
+		if extractOpts.ColumnDictionary, err = generator.LoadColumnDictionary(path); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range command.StringSlice("mmdb") {
+		database, err := mmdb.Open(path)
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to open --mmdb database: %v", err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		logger.Logger.Debug().Msgf("Annotating IPs with %s (%s)", path, database.DatabaseType)
+		extractOpts.IpDatabases = append(extractOpts.IpDatabases, database)
+	}
+
+	for _, class := range command.StringSlice("drop-ip-classes") {
+		class = strings.ToLower(class)
+		switch class {
+		case constants.IpClassesDropNone:
+		case constants.IpClassVersion:
+			extractOpts.DropVersionIps = true
+		default:
+			extractOpts.DroppedIpClasses = append(extractOpts.DroppedIpClasses, constants.IpClassNames[class])
+		}
+	}
+
+	if path := command.String("ip-denylist"); path != "" {
+		if extractOpts.IpDenylist, err = generator.LoadIpDenylist(path); err != nil {
+			return err
+		}
+	}
+
+	if path := command.String("noise-filter"); path != "" {
+		rules, err := noise.Load(path)
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to load --noise-filter list: %v", err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		logger.Logger.Debug().Msgf("Filtering noise with %d rules from %s", len(rules), path)
+
+		extractOpts.NoiseFilters = make(map[infoproto.Bucket]*noise.Filter)
+		for _, bucket := range constants.BucketNames {
+			if filter := noise.NewFilter(rules, bucket); !filter.Empty() {
+				extractOpts.NoiseFilters[bucket] = filter
+			}
+		}
+	}
+
+	if extractOpts.RecordMode == constants.RecordModeStart {
+		if command.String("record-start") == "" {
+			var msg = "--records start requires --record-start"
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		if extractOpts.RecordStart, err = regexp.Compile(command.String("record-start")); err != nil {
+			var msg = fmt.Sprintf("Invalid --record-start pattern %q: %v", command.String("record-start"), err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+	}
+
+	if len(extractOpts.HmacKey) == 0 {
+		if extractOpts.PasswordMode == constants.PasswordModeHash {
+			var msg = "--password-mode hash requires --hmac-key"
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		if extractOpts.PrivacyMode == constants.PrivacyModeHmac {
+			var msg = "--privacy-mode hmac requires --hmac-key"
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		logger.Logger.Warn().Msg("No --hmac-key given, payment cards and IBANs will only be stored masked")
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
 	// Initialize progress bar
@@ -82,59 +190,18 @@ func Action(ctx context.Context, command *ucli.Command) error {
 	//
 	// Extracting from directories
 	//
-	var wg sync.WaitGroup
-	maxThreads := int(command.Int("threads"))
-	semaphore := make(chan struct{}, maxThreads)
-
 	for _, inputDirectory := range inputList {
-		metadataList, err := logic.ProcessDirectory(globalProgress, &wg, semaphore, inputDirectory, command)
+		written, err := logic.ProcessDirectory(globalProgress, inputDirectory, command, extractOpts)
 		if err != nil {
 			globalProgress.GlobalTracker.IncrementWithError(1)
-		} else {
-			// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-			//
-			// Saving metadata
-			//
-			logger.Logger.Trace().Msgf("Locking slot for saving: %s", inputDirectory)
-			semaphore <- struct{}{}
-			wg.Add(1)
-
-			go func(ipd string, ml *metadataproto.MetadataList) {
-				defer func() {
-					logger.Logger.Trace().Msgf("Releasing slot for saving: %s", ipd)
-					<-semaphore
-					wg.Done()
-				}()
-
-				metadataFilePath := filepath.Join(ipd, "_metadata.pb")
-
-				data, err := proto.Marshal(ml)
-				if err != nil {
-					var msg = fmt.Sprintf("Error encoding metadata %s: %v", metadataFilePath, err)
-					logger.Logger.Error().Msg(msg)
-					globalProgress.GlobalTracker.IncrementWithError(1)
-
-					return
-				}
-
-				err = os.WriteFile(metadataFilePath, data, 0644)
-				if err != nil {
-					var msg = fmt.Sprintf("Error creating metadata %s: %v", metadataFilePath, err)
-					logger.Logger.Error().Msg(msg)
-					globalProgress.GlobalTracker.IncrementWithError(1)
-
-					return
-				}
-
-				globalProgress.GlobalTracker.Increment(1)
-			}(inputDirectory, metadataList)
-			// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+			continue
 		}
+
+		logger.Logger.Debug().Msgf("Saved %d metadata items for %s", written, inputDirectory)
+		globalProgress.GlobalTracker.Increment(1)
 	}
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	wg.Wait()
-
 	logger.Logger.Info().Msg("Done!")
 
 	return nil
@@ -1,8 +1,13 @@
 package extract
 
 import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
 	ucli "github.com/urfave/cli/v3"
+	"maps"
 	"runtime"
+	"slices"
+	"strings"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -33,4 +38,99 @@ var Flags = []ucli.Flag{
 		Usage: "Overwrite existing _metadata.pb",
 		Value: false,
 	},
+	&ucli.BoolFlag{
+		Name:  "incremental",
+		Usage: "Only extract parts that are new or changed (content hash or extractor version) and merge them into existing _metadata.pb",
+		Value: false,
+	},
+	&ucli.StringFlag{
+		Name:    "hmac-key",
+		Sources: ucli.EnvVars("HMAC_KEY"),
+		Usage:   "Key used to hash sensitive values (payment cards, IBANs, every entity with --privacy-mode hmac)",
+		Value:   "",
+	},
+	&ucli.StringFlag{
+		Name:  "column-dictionary",
+		Usage: "JSON file of extra CSV header rules: [{\"type\": \"email\", \"patterns\": [\"^courriel$\"]}]",
+		Value: "",
+	},
+	&ucli.StringFlag{
+		Name:  "password-mode",
+		Usage: "How passwords paired with an email are stored: classify (plaintext/hashed/empty only) or hash (keyed hash, needs --hmac-key)",
+		Value: constants.PasswordModeClassify,
+		Validator: func(s string) error {
+			switch strings.ToLower(s) {
+			case
+				constants.PasswordModeClassify,
+				constants.PasswordModeHash:
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.PasswordModes, " or "), s)
+		},
+	},
+	&ucli.StringFlag{
+		Name:  "privacy-mode",
+		Usage: "How entities are stored: plaintext or hmac (normalized then keyed hash, needs --hmac-key, exact-match lookups only)",
+		Value: constants.PrivacyModePlaintext,
+		Validator: func(s string) error {
+			switch strings.ToLower(s) {
+			case
+				constants.PrivacyModePlaintext,
+				constants.PrivacyModeHmac:
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.PrivacyModes, " or "), s)
+		},
+	},
+	&ucli.StringFlag{
+		Name:  "records",
+		Usage: "How lines are grouped into records: auto (sniffed \"key: value\" blocks, vCard, LDIF), line, blank, separator (\"=====\" lines) or start (--record-start)",
+		Value: constants.RecordModeAuto,
+		Validator: func(s string) error {
+			if slices.Contains(constants.RecordModes, strings.ToLower(s)) {
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.RecordModes, ", "), s)
+		},
+	},
+	&ucli.StringFlag{
+		Name:  "record-start",
+		Usage: "Regex matching the first line of each record, with --records start",
+		Value: "",
+	},
+	&ucli.StringSliceFlag{
+		Name:  "mmdb",
+		Usage: "MaxMind DB file (GeoLite2 Country/ASN or compatible) annotating IPs with their country, ASN and organization, once per database",
+	},
+	&ucli.StringSliceFlag{
+		Name:  "drop-ip-classes",
+		Usage: "IP classes left out of the metadata: public, private, cgnat, loopback, link-local, multicast, documentation, reserved, version (\"version 1.2.3.4\" like numbers) or none, e.g. " + strings.Join(constants.IpClassesNoise, ",") + " to drop the usual noise",
+		Value: []string{constants.IpClassesDropNone},
+		Validator: func(classes []string) error {
+			for _, class := range classes {
+				class = strings.ToLower(class)
+				if _, ok := constants.IpClassNames[class]; ok || class == constants.IpClassVersion || class == constants.IpClassesDropNone {
+					continue
+				}
+				return fmt.Errorf(
+					"expected IP classes among %s, %s or %s, got: %s",
+					strings.Join(slices.Sorted(maps.Keys(constants.IpClassNames)), ", "),
+					constants.IpClassVersion,
+					constants.IpClassesDropNone,
+					class,
+				)
+			}
+			return nil
+		},
+	},
+	&ucli.StringFlag{
+		Name:  "ip-denylist",
+		Usage: "File of IPs and CIDR networks (one per line, # comments) left out of the metadata, e.g. sinkholes",
+		Value: "",
+	},
+	&ucli.StringFlag{
+		Name:  "noise-filter",
+		Usage: "JSON file of domains and emails left out of the metadata: [{\"type\": \"domain\", \"exact\": [...], \"suffix\": [\"w3.org\"], \"regex\": [...], \"buckets\": [\"leaks.logs\"]}]",
+		Value: "",
+	},
 }
@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/app/extract/logic/generator"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type PartResult struct {
+	Index    int // position of the part in the sorted path list
+	Path     string
+	Metadata *metadataproto.Metadata
+	Raw      []byte                   // marshaled item copied from the previous _metadata.pb
+	Stats    *metadataproto.PartStats // of the Raw item
+	Err      error
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MetadataCollector receives one PartResult per queued part, in any order, and streams the extracted items in
+// queue order. It is owned by a single goroutine.
+type MetadataCollector struct {
+	writer  *utils.DelimitedWriter
+	pending map[int]PartResult
+	summary *generator.SummaryBuilder
+	next    int
+	err     error
+
+	Written int
+	Reused  int
+	Failed  int
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewMetadataCollector writes the privacy and the options fingerprint first, so readers know how to take the items
+// that follow.
+func NewMetadataCollector(
+	writer *utils.DelimitedWriter,
+	privacy *metadataproto.Privacy,
+	optionsFingerprint string,
+) *MetadataCollector {
+	err := writer.Write(constants.MetadataListPrivacyField, privacy)
+	if err == nil {
+		err = writer.WriteRaw(constants.MetadataListOptionsField, []byte(optionsFingerprint))
+	}
+
+	return &MetadataCollector{
+		writer:  writer,
+		pending: make(map[int]PartResult),
+		summary: generator.NewSummaryBuilder(),
+		err:     err,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Add stores the result and returns how many parts were flushed, in order, by this call.
+func (c *MetadataCollector) Add(result PartResult) int {
+	if _, exists := c.pending[result.Index]; exists || result.Index < c.next {
+		if c.err == nil {
+			c.err = fmt.Errorf("part %s (#%d) collected twice", result.Path, result.Index)
+		}
+		return 0
+	}
+
+	c.pending[result.Index] = result
+
+	flushed := 0
+	for {
+		next, ok := c.pending[c.next]
+		if !ok {
+			break
+		}
+
+		delete(c.pending, c.next)
+		c.next++
+		flushed++
+
+		switch {
+		case next.Err != nil || (next.Metadata == nil && next.Raw == nil):
+			c.Failed++
+			c.summary.AddFailed()
+		case c.err == nil && next.Raw != nil:
+			if c.err = c.writer.WriteRaw(constants.MetadataListItemsField, next.Raw); c.err == nil {
+				c.Written++
+				c.Reused++
+				c.summary.Add(next.Stats)
+			}
+		case c.err == nil:
+			if c.err = c.writer.Write(constants.MetadataListItemsField, next.Metadata); c.err == nil {
+				c.Written++
+				c.summary.Add(next.Metadata.Stats)
+			}
+		}
+	}
+
+	return flushed
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Summary folds the stats of the parts flushed so far.
+func (c *MetadataCollector) Summary() *metadataproto.DirectorySummary {
+	return c.summary.Summary()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Close checks every part up to expected was collected, then appends the summary and publishes the stream.
+func (c *MetadataCollector) Close(expected int) error {
+	if c.err == nil && (c.next != expected || len(c.pending) != 0) {
+		c.err = fmt.Errorf("collected %d of %d parts (%d out of order)", c.next, expected, len(c.pending))
+	}
+
+	if c.err == nil {
+		c.err = c.writer.Write(constants.MetadataListSummaryField, c.Summary())
+	}
+
+	if c.err != nil {
+		c.writer.Abort()
+		return c.err
+	}
+
+	return c.writer.Close()
+}
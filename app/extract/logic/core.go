@@ -3,16 +3,22 @@ package logic
 import (
 	"fmt"
 	"github.com/Rom1-J/preprocessor/app/extract/logic/generator"
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/archive"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
 	infoproto "github.com/Rom1-J/preprocessor/proto/info"
 	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
 	"github.com/jedib0t/go-pretty/v6/progress"
 	"github.com/urfave/cli/v3"
 	"google.golang.org/protobuf/proto"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -22,27 +28,61 @@ import (
 
 func ProcessDirectory(
 	globalProgress prog.ProgressOptsStruct,
-	wg *sync.WaitGroup,
-	semaphore chan struct{},
 	inputDirectory string,
 	command *cli.Command,
-) (*metadataproto.MetadataList, error) {
+	extractOpts structs.ExtractOptsStruct,
+) (int, error) {
 	logger.Logger.Trace().Msgf("ProcessDirectory starting on: %s", inputDirectory)
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Skip if _metadata.pb exists
+	// Skip if _metadata.pb exists, or load its items when extracting incrementally
 	//
 	metadataFilePath := filepath.Join(inputDirectory, "_metadata.pb")
 
+	var previousParts map[string]PreviousPart
+
+	privacy := generator.Privacy(extractOpts)
+	optionsFingerprint := generator.OptionsFingerprint(extractOpts)
+
 	if _, err := os.Stat(metadataFilePath); err == nil {
-		if !command.Bool("overwrite") {
+		switch {
+		case command.Bool("incremental"):
+			var (
+				previousPrivacy            *metadataproto.Privacy
+				previousOptionsFingerprint string
+			)
+
+			previousParts, previousPrivacy, previousOptionsFingerprint, err = LoadPreviousParts(metadataFilePath)
+			if err != nil {
+				return 0, err
+			}
+
+			// items hashed differently, or not at all, must not end up in the same stream
+			if !SamePrivacy(previousPrivacy, privacy) {
+				logger.Logger.Warn().Msgf(
+					"Existing %s was extracted with another privacy mode or key, extracting every part again",
+					metadataFilePath,
+				)
+				previousParts = nil
+			}
+
+			// nor items extracted under other filters, dictionaries or databases
+			if previousParts != nil && previousOptionsFingerprint != optionsFingerprint {
+				logger.Logger.Warn().Msgf(
+					"Existing %s was extracted with other options, extracting every part again",
+					metadataFilePath,
+				)
+				previousParts = nil
+			}
+
+		case !command.Bool("overwrite"):
 			message := fmt.Sprintf(
 				"Skipping directory '%s', use --overwrite to ignore existing _metadata.pb",
 				inputDirectory,
 			)
 
-			return nil, fmt.Errorf(message)
+			return 0, fmt.Errorf(message)
 		}
 	}
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
@@ -90,21 +130,64 @@ func ProcessDirectory(
 	tracker.UpdateTotal(int64(len(paths)))
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	metadataList := &metadataproto.MetadataList{}
-
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Process paths
+	// Formalize and check path existence, in path order
 	//
-	for path, metadataInfo := range paths {
-		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-		//
-		// Formalize and check path existence
-		//
+	type extractJob struct {
+		index        int
+		path         string
+		open         generator.PartOpener
+		metadataInfo *infoproto.MetadataInfo
+		previous     *PreviousPart // set when the existing item can be copied as is
+	}
+
+	var (
+		queue []extractJob
+
+		archivePath   string
+		archiveFrames []archive.ZstdFrame
+	)
+
+	if metadataInfo.Storage == infoproto.Storage_STORAGE_ARCHIVE {
+		// entries are read from the kept archive, nothing was unpacked to disk
+		archivePath = filepath.Join(inputDirectory, "data", string(metadataInfo.Path))
+		archiveFrames = generator.ArchiveFrames(metadataInfo)
+
+		if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+			var msg = fmt.Sprintf("Archive %s does not exist", archivePath)
+			logger.Logger.Error().Msg(msg)
+			tracker.MarkAsErrored()
+
+			return 0, fmt.Errorf(msg)
+		}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		metadataInfo := paths[path]
+
+		if archivePath != "" {
+			queue = append(queue, extractJob{
+				index:        len(queue),
+				path:         path,
+				open:         generator.ArchiveOpener(archivePath, archiveFrames, metadataInfo),
+				metadataInfo: metadataInfo,
+			})
+
+			continue
+		}
+
 		var dataFilePath string
 		parts := strings.Split(path, "/")
 
-		if !strings.HasSuffix(parts[0], ".chunked") || len(metadataInfo.Children) > 0 {
+		// chunked files, split mailboxes and messages and converted office files keep their directory, input
+		// directories are data/ itself
+		splitFile := strings.HasSuffix(parts[0], ".chunked") ||
+			strings.HasSuffix(parts[0], constants.MailboxMessagesSuffix) ||
+			strings.HasSuffix(parts[0], constants.MessagePartsSuffix) ||
+			strings.HasSuffix(parts[0], constants.OfficeConvertedSuffix)
+
+		if len(parts) > 1 && (!splitFile || len(metadataInfo.Children) > 0) {
 			dataFilePath = filepath.Join(
 				inputDirectory,
 				"data",
@@ -120,36 +203,143 @@ func ProcessDirectory(
 
 		if _, err := os.Stat(dataFilePath); os.IsNotExist(err) {
 			logger.Logger.Warn().Msgf("Skipping path '%s', file does not exist: %s", dataFilePath, path)
+			tracker.Increment(1)
+
 			continue
 		}
-		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
-
-		logger.Logger.Trace().Msgf("Locking slot for: %s", dataFilePath)
-		semaphore <- struct{}{}
-		wg.Add(1)
-
-		go func(p string, i *infoproto.MetadataInfo) {
-			defer func() {
-				tracker.Increment(1)
-
-				logger.Logger.Trace().Msgf("Releasing slot for: %s", p)
-				<-semaphore
-				wg.Done()
-			}()
-
-			// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-			//
-			// Extracting metadata from paths
-			//
-			metadata, err := generator.Extract(p, i)
-			if err != nil {
-				logger.Logger.Error().Msgf("Error starting extractor for file %s: %v", p, err)
-				return
+
+		queue = append(queue, extractJob{
+			index:        len(queue),
+			path:         dataFilePath,
+			open:         generator.FileOpener(dataFilePath),
+			metadataInfo: metadataInfo,
+		})
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Incremental: parts with the same hash and extractor version keep their existing item
+	//
+	var previousFile *os.File
+
+	if previousParts != nil {
+		reused := 0
+
+		for i := range queue {
+			if previous, ok := previousParts[queue[i].metadataInfo.Id]; ok && previous.Unchanged(queue[i].metadataInfo) {
+				queue[i].previous = &previous
+				reused++
+			}
+		}
+
+		logger.Logger.Info().Msgf(
+			"Incremental extraction of %s: %d parts unchanged, %d to extract",
+			inputDirectory, reused, len(queue)-reused,
+		)
+
+		// the new stream is written next to the old one and only renamed over it once complete
+		if previousFile, err = os.Open(metadataFilePath); err != nil {
+			var msg = fmt.Sprintf("Failed to open metadata file %s: %v", metadataFilePath, err)
+			logger.Logger.Error().Msg(msg)
+			tracker.MarkAsErrored()
+
+			return 0, fmt.Errorf(msg)
+		}
+		defer func(previousFile *os.File) {
+			if err := previousFile.Close(); err != nil {
+				logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", metadataFilePath, err)
+			}
+		}(previousFile)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Open output stream, items are appended in path order as soon as they are extracted
+	//
+	writer, err := utils.CreateDelimitedWriter(metadataFilePath)
+	if err != nil {
+		tracker.MarkAsErrored()
+
+		return 0, err
+	}
+
+	collector := NewMetadataCollector(writer, privacy, optionsFingerprint)
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Start extraction workers, every queued part yields exactly one result
+	//
+	threads := int(command.Int("threads"))
+	if threads < 1 {
+		threads = 1
+	}
+
+	jobs := make(chan extractJob, threads)
+	results := make(chan PartResult, threads)
+
+	var workers sync.WaitGroup
+
+	for range threads {
+		workers.Add(1)
+
+		go func() {
+			defer workers.Done()
+
+			for job := range jobs {
+				if job.previous != nil {
+					raw, err := job.previous.Read(previousFile)
+					if err != nil {
+						logger.Logger.Error().Msgf("Error reading previous item for file %s: %v", job.path, err)
+					}
+
+					results <- PartResult{Index: job.index, Path: job.path, Raw: raw, Stats: job.previous.Stats, Err: err}
+					continue
+				}
+
+				metadata, err := generator.Extract(job.open, job.metadataInfo, extractOpts)
+				if err != nil {
+					logger.Logger.Error().Msgf("Error starting extractor for file %s: %v", job.path, err)
+				}
+
+				results <- PartResult{Index: job.index, Path: job.path, Metadata: metadata, Err: err}
 			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Queue parts, the window bounds how many results may wait behind a slow part
+	//
+	window := make(chan struct{}, threads*constants.ExtractPendingPerThread)
+
+	go func() {
+		for _, job := range queue {
+			window <- struct{}{}
+			jobs <- job
+		}
 
-			metadataList.Items = append(metadataList.Items, metadata)
-			// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
-		}(dataFilePath, metadataInfo)
+		close(jobs)
+	}()
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Collect results
+	//
+	for result := range results {
+		for range collector.Add(result) {
+			<-window
+			tracker.Increment(1)
+		}
 	}
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
@@ -157,10 +347,37 @@ func ProcessDirectory(
 	//
 	// Closing output descriptor
 	//
-	wg.Wait()
+	if err := collector.Close(len(queue)); err != nil {
+		tracker.MarkAsErrored()
+
+		var msg = fmt.Sprintf("Error writing metadata %s: %v", metadataFilePath, err)
+		logger.Logger.Error().Msg(msg)
+
+		return collector.Written, fmt.Errorf(msg)
+	}
+
+	if collector.Failed > 0 {
+		logger.Logger.Warn().Msgf("%d of %d parts failed to extract in %s", collector.Failed, len(queue), inputDirectory)
+	}
+
+	summary := collector.Summary()
+	logger.Logger.Info().Msgf(
+		"Extracted %s: %d lines, %d bytes, %d empty, %d binary-looking, longest line %d, %s",
+		inputDirectory,
+		summary.Totals.Lines,
+		summary.Totals.Bytes,
+		summary.Totals.EmptyLines,
+		summary.Totals.BinaryLines,
+		summary.Totals.LongestLine,
+		generator.FormatDateRange(summary.Totals.EarliestDate, summary.Totals.LatestDate),
+	)
+
+	for _, filtered := range summary.Totals.Filtered {
+		logger.Logger.Info().Msgf("Noise filter %q removed %d entities from %s", filtered.Name, filtered.Count, inputDirectory)
+	}
 
 	tracker.MarkAsDone()
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	return metadataList, nil
+	return collector.Written, nil
 }
@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"github.com/Rom1-J/preprocessor/pkg/archive"
 	"github.com/Rom1-J/preprocessor/pkg/utils"
 	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	"io"
 	"path/filepath"
 )
 
@@ -30,3 +32,29 @@ func RetrieveReadableFilePaths(metadata *infoproto.MetadataInfo) map[string]*inf
 
 	return fileMap
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ArchiveFrames(metadata *infoproto.MetadataInfo) []archive.ZstdFrame {
+	frames := make([]archive.ZstdFrame, 0, len(metadata.Frames))
+
+	for _, frame := range metadata.Frames {
+		frames = append(frames, archive.ZstdFrame{
+			CompressedOffset:   frame.CompressedOffset,
+			CompressedSize:     frame.CompressedSize,
+			DecompressedOffset: frame.DecompressedOffset,
+			DecompressedSize:   frame.DecompressedSize,
+		})
+	}
+
+	return frames
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ArchiveOpener(archivePath string, frames []archive.ZstdFrame, entry *infoproto.MetadataInfo) PartOpener {
+	return func() (io.ReadCloser, error) {
+		return archive.OpenZstdEntry(archivePath, frames, entry.ArchiveOffset, entry.Size)
+	}
+}
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"bufio"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sampleLines returns the non-blank lines of the sample, the last one being dropped as it may be cut.
+func sampleLines(sample []byte) []string {
+	lines := strings.Split(string(sample), "\n")
+	if len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+
+	var nonBlank []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			nonBlank = append(nonBlank, line)
+		}
+	}
+
+	return nonBlank
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsCookieSample tells Netscape cookie files apart, most sampled lines but comments being cookies.
+func IsCookieSample(sample []byte) bool {
+	var lines, cookies int
+
+	for _, line := range sampleLines(sample) {
+		if constants.CookieLinePattern.MatchString(line) {
+			cookies++
+		} else if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines++
+	}
+
+	return cookies >= constants.RecordSniffMinimum && float64(cookies) >= constants.RecordSniffRatio*float64(lines)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsAutofillSample tells autofill dumps apart, most sampled lines being "Name:" / "Value:" pairs.
+func IsAutofillSample(sample []byte) bool {
+	var (
+		lines       = sampleLines(sample)
+		pairedLines int
+	)
+
+	for i := 0; i+1 < len(lines); i++ {
+		if constants.AutofillNamePattern.MatchString(lines[i]) && constants.AutofillValuePattern.MatchString(lines[i+1]) {
+			pairedLines += 2
+			i++
+		}
+	}
+
+	return pairedLines >= 2*constants.RecordSniffMinimum &&
+		float64(pairedLines) >= constants.RecordSniffRatio*float64(len(lines))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanCookies keeps the domain, name, expiry and flags of each cookie, its value being only hashed: session tokens
+// are neither scanned nor stored. Other lines are scanned as usual.
+func scanCookies(reader *bufio.Reader, fragments *Fragments) {
+	forEachLine(reader, func(line string) {
+		match := constants.CookieLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			fragments.ScanLine(line)
+			return
+		}
+
+		domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(match[2]), "."))
+		expiry, _ := strconv.ParseFloat(match[4], 64)
+
+		cookie := &metadataproto.Cookie{
+			Domain:    []byte(domain),
+			Name:      match[5],
+			Secure:    strings.EqualFold(match[3], "TRUE"),
+			HttpOnly:  match[1] != "",
+			ValueHash: utils.KeyedHash(fragments.extractOpts.HmacKey, []byte(match[6])),
+		}
+		if expiry > 0 {
+			cookie.Expiry = uint64(expiry)
+		}
+
+		fragments.cookies = append(fragments.cookies, cookie)
+		fragments.domains = append(fragments.domains, domain)
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanAutofill numbers each "Name:" / "Value:" pair as a record, the value being scanned and its entities sourced
+// from the field name.
+func scanAutofill(reader *bufio.Reader, fragments *Fragments) {
+	var (
+		name    string
+		hasName bool
+	)
+
+	forEachLine(reader, func(line string) {
+		if match := constants.AutofillNamePattern.FindStringSubmatch(line); match != nil {
+			name, hasName = match[1], true
+			return
+		}
+
+		match := constants.AutofillValuePattern.FindStringSubmatch(line)
+		if match == nil || !hasName {
+			hasName = false
+			fragments.ScanLine(line)
+			return
+		}
+		hasName = false
+
+		fragments.records++
+		sourcedCount := len(fragments.sourced)
+
+		fragments.autofill = append(fragments.autofill, &metadataproto.AutofillField{
+			Name:   name,
+			Value:  []byte(match[1]),
+			Record: fragments.records,
+		})
+		fragments.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL, NormalizeColumnName(name), match[1])
+
+		for _, entity := range fragments.sourced[sourcedCount:] {
+			entity.Record = fragments.records
+		}
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func forEachLine(reader *bufio.Reader, visit func(line string)) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			logger.Logger.Warn().Err(err).Msgf("Error reading line: %s: %s", line, err)
+			break
+		}
+
+		if line != "" {
+			visit(strings.TrimRight(line, "\r\n"))
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package generator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type CsvLayout struct {
+	Delimiter rune
+	Header    []string
+	Types     []string // entity type of each column, empty when unknown
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func LoadColumnDictionary(path string) ([]constants.ColumnRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		var msg = fmt.Sprintf("Failed to read column dictionary %s: %v", path, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	var entries []struct {
+		Type     string   `json:"type"`
+		Patterns []string `json:"patterns"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var msg = fmt.Sprintf("Failed to parse column dictionary %s: %v", path, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// User rules are evaluated before the default ones
+	//
+	var dictionary []constants.ColumnRule
+
+	for _, entry := range entries {
+		for _, pattern := range entry.Patterns {
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				var msg = fmt.Sprintf("Invalid column pattern %q for %s: %v", pattern, entry.Type, err)
+				logger.Logger.Error().Msg(msg)
+
+				return nil, fmt.Errorf(msg)
+			}
+
+			dictionary = append(dictionary, constants.ColumnRule{Type: entry.Type, Pattern: compiled})
+		}
+	}
+
+	dictionary = append(dictionary, constants.ColumnDictionary...)
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return dictionary, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ColumnType(name string, dictionary []constants.ColumnRule) string {
+	normalized := NormalizeColumnName(name)
+
+	for _, rule := range dictionary {
+		if rule.Pattern.MatchString(normalized) {
+			return rule.Type
+		}
+	}
+
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NormalizeColumnName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(strings.TrimSpace(name)))
+
+	return strings.Trim(name, "_")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func splitCsvRow(line string, delimiter rune) []string {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	fields, err := reader.Read()
+	if err != nil {
+		return nil
+	}
+
+	return fields
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func SniffCsvLayout(sample []byte, dictionary []constants.ColumnRule) *CsvLayout {
+	lines := strings.Split(string(sample), "\n")
+	if len(sample) == constants.CsvSniffSize {
+		// last line is most likely truncated
+		lines = lines[:len(lines)-1]
+	}
+
+	var rows []string
+	for _, line := range lines {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			rows = append(rows, line)
+		}
+		if len(rows) == constants.CsvSniffLines {
+			break
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Pick the delimiter splitting the header in the most fields, consistently across sampled rows
+	//
+	var (
+		bestDelimiter rune
+		bestHeader    []string
+	)
+
+	for _, delimiter := range constants.CsvDelimiters {
+		header := splitCsvRow(rows[0], delimiter)
+		if len(header) < 2 || len(header) <= len(bestHeader) {
+			continue
+		}
+
+		consistent := 0
+		for _, row := range rows[1:] {
+			if len(splitCsvRow(row, delimiter)) == len(header) {
+				consistent++
+			}
+		}
+
+		if len(rows) > 1 && float64(consistent) < constants.CsvSniffRatio*float64(len(rows)-1) {
+			continue
+		}
+
+		bestDelimiter, bestHeader = delimiter, header
+	}
+
+	if bestHeader == nil {
+		return nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Map header names to entity types, a file without any known column is not treated as CSV
+	//
+	layout := &CsvLayout{
+		Delimiter: bestDelimiter,
+		Header:    make([]string, len(bestHeader)),
+		Types:     make([]string, len(bestHeader)),
+	}
+	known := false
+
+	for i, name := range bestHeader {
+		layout.Header[i] = strings.TrimSpace(name)
+		layout.Types[i] = ColumnType(name, dictionary)
+		known = known || layout.Types[i] != ""
+	}
+
+	if !known {
+		return nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return layout
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (l *CsvLayout) Record(line string) []RecordField {
+	var record []RecordField
+
+	for i, value := range splitCsvRow(strings.TrimRight(line, "\r\n"), l.Delimiter) {
+		if i >= len(l.Header) {
+			break
+		}
+
+		record = append(record, RecordField{Source: l.Header[i], Type: l.Types[i], Value: strings.TrimSpace(value)})
+	}
+
+	return record
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NormalizeColumnValue(entityType string, value string) (string, string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", false
+	}
+
+	switch entityType {
+	case constants.EntityTypeEmail:
+		if constants.EmailPattern.FindString(value) == value {
+			return entityType, value, true
+		}
+
+	case constants.EntityTypeIp:
+		if net.ParseIP(value) != nil {
+			return entityType, value, true
+		}
+
+	case constants.EntityTypeDomain:
+		if domain := constants.DomainPattern.FindString(value); domain != "" {
+			return entityType, domain, true
+		}
+
+	case constants.EntityTypePhone:
+		if phone := constants.PhoneSeparatorPattern.ReplaceAllString(value, ""); constants.PhonePattern.MatchString(phone) {
+			return entityType, phone, true
+		}
+
+	case constants.EntityTypeUsername:
+		// login columns often hold email addresses
+		if constants.EmailPattern.FindString(value) == value {
+			return constants.EntityTypeEmail, value, true
+		}
+		if len(value) <= 64 && !strings.ContainsAny(value, " \t") {
+			return entityType, value, true
+		}
+
+	case constants.EntityTypePassword:
+		// passwords are only kept paired with an email, see CredentialScanner
+
+	case constants.EntityTypeTimestamp:
+		// timestamps only make the date range of the part, see DateRange
+
+	default:
+		// user-defined types are kept as is
+		return entityType, value, true
+	}
+
+	return "", "", false
+}
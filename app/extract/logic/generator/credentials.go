@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type CredentialScanner struct {
+	extractOpts structs.ExtractOptsStruct
+
+	pendingEmail string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewCredentialScanner(extractOpts structs.ExtractOptsStruct) *CredentialScanner {
+	return &CredentialScanner{extractOpts: extractOpts}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ClassifyPassword(password string) (metadataproto.PasswordKind, string) {
+	if password == "" {
+		return metadataproto.PasswordKind_PASSWORD_KIND_EMPTY, ""
+	}
+
+	for _, format := range constants.HashFormats {
+		if loc := format.Pattern.FindStringIndex(password); loc != nil && loc[0] == 0 && loc[1] == len(password) {
+			return metadataproto.PasswordKind_PASSWORD_KIND_HASHED, format.Algorithm
+		}
+	}
+
+	if constants.HexHashPattern.FindString(password) == password && isHexDigest(password) {
+		if hashType, ok := constants.HexHashLengths[len(password)]; ok {
+			return metadataproto.PasswordKind_PASSWORD_KIND_HASHED, hashType.Algorithm
+		}
+	}
+
+	return metadataproto.PasswordKind_PASSWORD_KIND_PLAINTEXT, ""
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *CredentialScanner) Pair(email string, password string) *metadataproto.Credential {
+	kind, algorithm := ClassifyPassword(password)
+
+	credential := &metadataproto.Credential{
+		Email:         []byte(email),
+		PasswordKind:  kind,
+		HashAlgorithm: algorithm,
+	}
+
+	if s.extractOpts.PasswordMode == constants.PasswordModeHash && kind != metadataproto.PasswordKind_PASSWORD_KIND_EMPTY {
+		credential.PasswordHash = utils.KeyedHash(s.extractOpts.HmacKey, []byte(password))
+	}
+
+	return credential
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *CredentialScanner) ScanLine(line string) []*metadataproto.Credential {
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// "Password:" line right after a "Login:" line
+	//
+	if s.pendingEmail != "" {
+		email := s.pendingEmail
+		s.pendingEmail = ""
+
+		if match := constants.PasswordLinePattern.FindStringSubmatch(line); match != nil {
+			return []*metadataproto.Credential{s.Pair(email, match[1])}
+		}
+	}
+
+	if match := constants.LoginLinePattern.FindStringSubmatch(line); match != nil {
+		s.pendingEmail = match[1]
+		return nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Combolist "email:password" lines
+	//
+	if match := constants.CombolistPattern.FindStringSubmatch(line); match != nil {
+		return []*metadataproto.Credential{s.Pair(match[1], match[2])}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DateRange keeps the earliest and latest timestamps of a part, as Unix seconds, anchoring on the '-', '/' and ' '
+// date separators instead of running the date patterns over every line.
+type DateRange struct {
+	Earliest uint64 // 0 when no timestamp was recognized
+	Latest   uint64
+
+	lowerBound int64
+	upperBound int64
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewDateRange() *DateRange {
+	return &DateRange{
+		lowerBound: constants.DateMinimum.Unix(),
+		upperBound: time.Now().Add(constants.DateFutureSlack).Unix(),
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FormatDateRange renders the range of a part or a directory for logs, e.g. "2019-05-04 to 2021-01-02".
+func FormatDateRange(earliest uint64, latest uint64) string {
+	if earliest == 0 {
+		return "no dates"
+	}
+
+	return time.Unix(int64(earliest), 0).UTC().Format(time.DateOnly) +
+		" to " +
+		time.Unix(int64(latest), 0).UTC().Format(time.DateOnly)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *DateRange) Add(date time.Time) {
+	seconds := date.Unix()
+	if seconds < r.lowerBound || seconds > r.upperBound {
+		return
+	}
+
+	if r.Earliest == 0 || uint64(seconds) < r.Earliest {
+		r.Earliest = uint64(seconds)
+	}
+	if uint64(seconds) > r.Latest {
+		r.Latest = uint64(seconds)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanText folds the ISO 8601, RFC 2822 and access log dates of text into the range.
+func (r *DateRange) ScanText(text string) {
+	for i := 1; i+3 < len(text); i++ {
+		switch c := text[i]; {
+		case (c == '-' || c == '/') && i >= 4 && isDigitRun(text, i-4, 4) && isDigitRun(text, i+1, 2):
+			if i == 4 || !is(text, i-5, classWord) {
+				r.scanAt(constants.IsoDatePattern, text[i-4:], false)
+			}
+
+		case (c == ' ' || c == '/') && is(text, i-1, classDigit) && isMonth(text, i+1):
+			start := i - 1
+			if start > 0 && is(text, start-1, classDigit) {
+				start--
+			}
+			if start > 0 && is(text, start-1, classWord) {
+				continue
+			}
+
+			if c == ' ' {
+				r.scanAt(constants.Rfc2822DatePattern, text[start:], true)
+			} else {
+				r.scanAt(constants.AccessLogDatePattern, text[start:], true)
+			}
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanValue folds the value of a timestamp column into the range, Unix epochs included.
+func (r *DateRange) ScanValue(value string) {
+	value = strings.TrimSpace(value)
+
+	match := constants.EpochPattern.FindStringSubmatch(value)
+	if match == nil {
+		r.ScanText(value)
+		return
+	}
+
+	epoch, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return
+	}
+
+	switch digits := len(match[1]); {
+	case digits <= 10:
+		r.Add(time.Unix(epoch, 0))
+	case digits <= 13:
+		r.Add(time.UnixMilli(epoch))
+	case digits <= 16:
+		r.Add(time.UnixMicro(epoch))
+	default:
+		r.Add(time.Unix(0, epoch))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanAt matches the pattern at the start of text, the day coming first when dayFirst.
+func (r *DateRange) scanAt(pattern *regexp.Regexp, text string, dayFirst bool) {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return
+	}
+
+	var year, month, day int
+	if dayFirst {
+		year, _ = strconv.Atoi(match[3])
+		month = int(constants.DateMonths[strings.ToLower(match[2])])
+		day, _ = strconv.Atoi(match[1])
+	} else {
+		year, _ = strconv.Atoi(match[1])
+		month, _ = strconv.Atoi(match[2])
+		day, _ = strconv.Atoi(match[3])
+	}
+
+	hour, _ := strconv.Atoi(match[4])
+	minute, _ := strconv.Atoi(match[5])
+	second, _ := strconv.Atoi(match[6])
+
+	if month < 1 || month > 12 || hour > 23 || minute > 59 || second > 60 {
+		return
+	}
+
+	date := time.Date(year, time.Month(month), day, hour, minute, second, 0, dateZone(match[7]))
+	if date.Day() != day {
+		// February 30th and such
+		return
+	}
+
+	r.Add(date)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isDigitRun(text string, start int, length int) bool {
+	if start < 0 || start+length > len(text) {
+		return false
+	}
+
+	for i := start; i < start+length; i++ {
+		if !is(text, i, classDigit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isMonth(text string, start int) bool {
+	if start+3 > len(text) {
+		return false
+	}
+
+	_, ok := constants.DateMonths[strings.ToLower(text[start:start+3])]
+
+	return ok
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dateZone parses "+02:00" and "+0200" offsets, named zones but UTC ones being taken as UTC as well.
+func dateZone(zone string) *time.Location {
+	if len(zone) < 5 || (zone[0] != '+' && zone[0] != '-') {
+		return time.UTC
+	}
+
+	digits := strings.ReplaceAll(zone[1:], ":", "")
+	hours, _ := strconv.Atoi(digits[:2])
+	minutes, _ := strconv.Atoi(digits[2:])
+
+	offset := hours*3600 + minutes*60
+	if zone[0] == '-' {
+		offset = -offset
+	}
+
+	return time.FixedZone(zone, offset)
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/Rom1-J/preprocessor/constants"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type DecodedBlob struct {
+	Encoding string
+	Text     string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isBase64Byte(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+		c == '+' || c == '/' || c == '-' || c == '_'
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isHexString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// isPrintable tells text from binary, control characters other than line breaks and tabs count against it.
+func isPrintable(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+
+	var printable, total int
+
+	for _, r := range string(data) {
+		total++
+		if unicode.IsPrint(r) || r == '\n' || r == '\r' || r == '\t' {
+			printable++
+		}
+	}
+
+	return float64(printable) >= constants.EncodedPrintableRatio*float64(total)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func decodeBase64(run string) ([]byte, bool) {
+	raw := strings.TrimRight(run, "=")
+
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(raw, "-_") {
+		if strings.ContainsAny(raw, "+/") {
+			return nil, false
+		}
+		encoding = base64.RawURLEncoding
+	}
+
+	// a 4n+1 long run cannot be base64, the first character is likely a prefix like in "=SGVsbG8"
+	if len(raw)%4 == 1 {
+		return nil, false
+	}
+
+	decoded, err := encoding.DecodeString(raw)
+
+	return decoded, err == nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DecodeBlobs returns the text hidden in the long base64 and hex runs and in the percent-encoded runs of text.
+func DecodeBlobs(text string) []DecodedBlob {
+	var blobs []DecodedBlob
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Base64 and hex runs, scanned by hand as most lines have none
+	//
+	for i := 0; i < len(text); {
+		if !isBase64Byte(text[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(text) && isBase64Byte(text[i]) {
+			i++
+		}
+		for padding := 0; padding < 2 && i < len(text) && text[i] == '='; padding++ {
+			i++
+		}
+
+		run := text[start:i]
+
+		var (
+			decoded  []byte
+			encoding string
+			ok       bool
+		)
+
+		switch {
+		case len(run) >= constants.EncodedMinHexLength && len(run)%2 == 0 && isHexString(run):
+			decoded, ok = make([]byte, len(run)/2), true
+			if _, err := hex.Decode(decoded, []byte(run)); err != nil {
+				ok = false
+			}
+			encoding = constants.EncodingHex
+
+		case len(run) >= constants.EncodedMinBase64Length:
+			decoded, ok = decodeBase64(run)
+			encoding = constants.EncodingBase64
+		}
+
+		if ok && isPrintable(decoded) {
+			blobs = append(blobs, DecodedBlob{Encoding: encoding, Text: string(decoded)})
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Percent-encoded runs, query strings keep their "&" and "=" so "email=...&pass=..." stays readable
+	//
+	if strings.IndexByte(text, '%') >= 0 {
+		for _, run := range constants.PercentEncodedPattern.FindAllString(text, -1) {
+			decoded, err := url.QueryUnescape(run)
+			if err != nil {
+				if decoded, err = url.PathUnescape(run); err != nil {
+					continue
+				}
+			}
+
+			if decoded != run && isPrintable([]byte(decoded)) {
+				blobs = append(blobs, DecodedBlob{Encoding: constants.EncodingPercent, Text: decoded})
+			}
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return blobs
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func keepAlphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(s))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsLuhnValid(digits string) bool {
+	var sum int
+	double := false
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsIbanValid(iban string) bool {
+	expectedLength, ok := constants.IbanLengths[iban[:2]]
+	if !ok || len(iban) != expectedLength {
+		return false
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Move country code and check digits to the end, then expand letters (A=10 .. Z=35)
+	//
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	value, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+
+	return new(big.Int).Mod(value, big.NewInt(97)).Int64() == 1
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func CardNetwork(pan string) string {
+	for _, network := range constants.CardNetworks {
+		lengthMatches := false
+		for _, length := range network.Lengths {
+			if len(pan) == length {
+				lengthMatches = true
+				break
+			}
+		}
+		if !lengthMatches {
+			continue
+		}
+
+		for _, prefixRange := range network.Prefixes {
+			width := len(strconv.Itoa(prefixRange[0]))
+			prefix, err := strconv.Atoi(pan[:width])
+			if err != nil {
+				continue
+			}
+
+			if prefix >= prefixRange[0] && prefix <= prefixRange[1] {
+				return network.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// maskVisible caps the characters a mask keeps to half of the value, the head shrinking before the tail.
+func maskVisible(length int, head int, tail int) (int, int) {
+	visible := min(head+tail, length/2)
+	tail = min(tail, visible)
+
+	return visible - tail, tail
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaskPan keeps at most the first 6 and last 4 digits, and never more than half of them, e.g. "50******1234" for
+// 12 digits.
+func MaskPan(pan string) []byte {
+	head, tail := maskVisible(len(pan), 6, 4)
+	return utils.Redact(pan, head, tail)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaskIban keeps at most the first 4 and last 4 characters.
+func MaskIban(iban string) []byte {
+	head, tail := maskVisible(len(iban), 4, 4)
+	return utils.Redact(iban, head, tail)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ExtractCards(line string, hmacKey []byte) []*metadataproto.PaymentCard {
+	var cards []*metadataproto.PaymentCard
+
+	for _, candidate := range constants.CardPattern.FindAllString(line, -1) {
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Try every run of consecutive digit groups, so "id 4111 1111 1111 1111 2024" still yields the PAN
+		//
+		groups := constants.CardSeparatorPattern.Split(candidate, -1)
+
+		for start := 0; start < len(groups); start++ {
+			var (
+				pan, bestPan, bestNetwork string
+				bestEnd                   int
+			)
+
+			for end := start; end < len(groups) && len(pan)+len(groups[end]) <= 19; end++ {
+				pan += groups[end]
+				if len(pan) < 12 || !IsLuhnValid(pan) {
+					continue
+				}
+
+				if network := CardNetwork(pan); network != "" {
+					bestPan, bestNetwork, bestEnd = pan, network, end
+				}
+			}
+
+			if bestPan == "" {
+				continue
+			}
+
+			cards = append(cards, &metadataproto.PaymentCard{
+				Masked:  MaskPan(bestPan),
+				Hash:    utils.KeyedHash(hmacKey, []byte(bestPan)),
+				Network: bestNetwork,
+			})
+
+			start = bestEnd
+		}
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+	}
+
+	return cards
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ExtractIbans(line string, hmacKey []byte) []*metadataproto.Iban {
+	var ibans []*metadataproto.Iban
+
+	for _, candidate := range constants.IbanPattern.FindAllString(line, -1) {
+		iban := keepAlphanumeric(candidate)
+
+		// greedy matches may swallow the following word, e.g. "FR76 ... 189 BIC"
+		if expectedLength, ok := constants.IbanLengths[iban[:2]]; ok && len(iban) > expectedLength {
+			iban = iban[:expectedLength]
+		}
+
+		if !IsIbanValid(iban) {
+			continue
+		}
+
+		ibans = append(ibans, &metadataproto.Iban{
+			Masked:  MaskIban(iban),
+			Hash:    utils.KeyedHash(hmacKey, []byte(iban)),
+			Country: iban[:2],
+		})
+	}
+
+	return ibans
+}
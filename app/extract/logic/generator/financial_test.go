@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func visibleCount(masked []byte) int {
+	return len(masked) - strings.Count(string(masked), "*")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestMaskPanKeepsAtMostHalf(t *testing.T) {
+	for _, test := range []struct {
+		pan     string
+		visible int
+	}{
+		{"501812345678", 6},
+		{"4111111111111", 6},
+		{"4111111111111111", 8},
+		{"6304123456789012345", 9},
+	} {
+		masked := MaskPan(test.pan)
+		if len(masked) != len(test.pan) {
+			t.Errorf("MaskPan(%s) = %s, length changed", test.pan, masked)
+		}
+		if got := visibleCount(masked); got != test.visible {
+			t.Errorf("MaskPan(%s) = %s, %d digits visible, expected %d", test.pan, masked, got, test.visible)
+		}
+		if !strings.HasSuffix(string(masked), test.pan[len(test.pan)-4:]) {
+			t.Errorf("MaskPan(%s) = %s, last 4 digits hidden", test.pan, masked)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestMaskIbanKeepsAtMostHalf(t *testing.T) {
+	for _, iban := range []string{"NO9386011117947", "BE68539007547034", "FR1420041010050500013M02606"} {
+		masked := MaskIban(iban)
+		if got := visibleCount(masked); got > len(iban)/2 || got > 8 {
+			t.Errorf("MaskIban(%s) = %s, %d of %d characters visible", iban, masked, got, len(iban))
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestCardNetworkMaestroPrefixes(t *testing.T) {
+	for pan, expected := range map[string]string{
+		"501800000000":       "maestro",
+		"6759000000000000":   "maestro",
+		"612345678906":       "",
+		"600000000000000000": "",
+	} {
+		if got := CardNetwork(pan); got != expected {
+			t.Errorf("CardNetwork(%s) = %q, expected %q", pan, got, expected)
+		}
+	}
+}
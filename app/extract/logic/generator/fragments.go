@@ -0,0 +1,307 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"slices"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type RecordField struct {
+	Source string // column name or JSON key path
+	Type   string // entity type from the column dictionary, empty when unknown
+	Value  string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type Fragments struct {
+	extractOpts structs.ExtractOptsStruct
+
+	emails      []string
+	ips         []string
+	domains     []string
+	cards       []*metadataproto.PaymentCard
+	ibans       []*metadataproto.Iban
+	secrets     []*metadataproto.Secret
+	credentials []*metadataproto.Credential
+	accounts    []*metadataproto.Account
+	sourced     []*metadataproto.SourcedEntity
+	cookies     []*metadataproto.Cookie
+	autofill    []*metadataproto.AutofillField
+
+	hashCounts  map[string]*metadataproto.HashCount
+	schemaCount map[string]uint64
+	records     uint64 // multi-line records scanned so far
+
+	invalidDocuments uint64 // malformed JSON documents skipped after the first one parsed
+	dates            *DateRange
+
+	encodings     []string // decoding chain of the blob being scanned, e.g. [percent base64]
+	decodedBudget int      // bytes left to decode for the current top-level text
+
+	entityScanner     *EntityScanner
+	secretScanner     *SecretScanner
+	credentialScanner *CredentialScanner
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewFragments(extractOpts structs.ExtractOptsStruct) *Fragments {
+	return &Fragments{
+		extractOpts:       extractOpts,
+		hashCounts:        make(map[string]*metadataproto.HashCount),
+		schemaCount:       make(map[string]uint64),
+		dates:             NewDateRange(),
+		entityScanner:     NewEntityScanner(),
+		secretScanner:     NewSecretScanner(extractOpts.HmacKey),
+		credentialScanner: NewCredentialScanner(extractOpts),
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) ScanText(text string) {
+	f.scanPlainText(text)
+	f.scanEncoded(text)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) scanPlainText(text string) {
+	emails, ips, domains := f.entityScanner.Scan(text)
+	ips = f.filterIps(text, ips)
+
+	f.emails = append(f.emails, emails...)
+	f.ips = append(f.ips, ips...)
+	f.domains = append(f.domains, domains...)
+	f.cards = append(f.cards, ExtractCards(text, f.extractOpts.HmacKey)...)
+	f.ibans = append(f.ibans, ExtractIbans(text, f.extractOpts.HmacKey)...)
+	CountHashes(text, f.hashCounts)
+	f.secrets = append(f.secrets, f.secretScanner.ScanLine(text)...)
+	f.dates.ScanText(text)
+	f.scanHandles(text)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) ScanSourcedText(origin metadataproto.EntityOrigin, source string, text string) {
+	emailCount, ipCount, domainCount := len(f.emails), len(f.ips), len(f.domains)
+
+	f.scanPlainText(text)
+
+	for _, found := range []struct {
+		entityType string
+		values     []string
+	}{
+		{constants.EntityTypeEmail, f.emails[emailCount:]},
+		{constants.EntityTypeIp, f.ips[ipCount:]},
+		{constants.EntityTypeDomain, f.domains[domainCount:]},
+	} {
+		for _, value := range found.values {
+			f.sourced = append(f.sourced, &metadataproto.SourcedEntity{
+				Type:   found.entityType,
+				Value:  []byte(value),
+				Origin: origin,
+				Source: source,
+			})
+		}
+	}
+
+	// blobs are tagged with their own origin
+	f.scanEncoded(text)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanEncoded runs the extractors on the decoded base64, hex and percent-encoded blobs of text, the entities found
+// being sourced from the decoding chain. Nested blobs are decoded up to EncodedMaxDepth, and at most EncodedMaxSize
+// bytes per top-level text.
+func (f *Fragments) scanEncoded(text string) {
+	if len(f.encodings) == 0 {
+		f.decodedBudget = constants.EncodedMaxSize
+	}
+	if len(f.encodings) >= constants.EncodedMaxDepth {
+		return
+	}
+
+	for _, blob := range DecodeBlobs(text) {
+		if len(blob.Text) > f.decodedBudget {
+			return
+		}
+		f.decodedBudget -= len(blob.Text)
+
+		f.encodings = append(f.encodings, blob.Encoding)
+		source := strings.Join(f.encodings, "/")
+
+		credentialScanner := NewCredentialScanner(f.extractOpts)
+		for _, line := range strings.Split(blob.Text, "\n") {
+			f.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_ENCODED, source, line)
+			f.credentials = append(f.credentials, credentialScanner.ScanLine(line)...)
+		}
+
+		f.encodings = f.encodings[:len(f.encodings)-1]
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) ScanLine(line string) {
+	f.ScanText(line)
+	f.scanUsernameLine(line)
+	f.credentials = append(f.credentials, f.credentialScanner.ScanLine(line)...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanRecord sources the typed fields of a record, and pairs its first email with its first password and username.
+func (f *Fragments) ScanRecord(origin metadataproto.EntityOrigin, fields []RecordField) {
+	var (
+		email, password, username          string
+		hasEmail, hasPassword, hasUsername bool
+		usernameSource                     string
+
+		// profile URLs stand for the username of records without a username field
+		handles []Handle
+	)
+
+	seen := make(map[string]bool)
+
+	for _, field := range fields {
+		if !seen[field.Source] {
+			seen[field.Source] = true
+			f.schemaCount[field.Source]++
+		}
+
+		if field.Type != constants.EntityTypePassword && len(handles) == 0 {
+			handles = ScanHandles(field.Value)
+		}
+
+		if field.Type == "" {
+			continue
+		}
+
+		if field.Type == constants.EntityTypeTimestamp {
+			f.dates.ScanValue(field.Value)
+		}
+
+		entityType, value, ok := NormalizeColumnValue(field.Type, field.Value)
+
+		switch {
+		case field.Type == constants.EntityTypePassword && !hasPassword:
+			password, hasPassword = field.Value, true
+		case entityType == constants.EntityTypeEmail && ok && !hasEmail:
+			email, hasEmail = value, true
+		case entityType == constants.EntityTypeUsername && ok && !hasUsername:
+			username, usernameSource, hasUsername = value, field.Source, true
+		}
+
+		if !ok || (entityType == constants.EntityTypeIp && !f.keepIp(value)) {
+			continue
+		}
+
+		f.sourced = append(f.sourced, &metadataproto.SourcedEntity{
+			Type:   entityType,
+			Value:  []byte(value),
+			Origin: origin,
+			Source: field.Source,
+		})
+	}
+
+	if hasEmail && hasPassword {
+		f.credentials = append(f.credentials, f.credentialScanner.Pair(email, password))
+	}
+
+	if !hasUsername && len(handles) > 0 {
+		username, usernameSource, hasUsername = handles[0].Username, handles[0].Source, true
+	}
+
+	if hasEmail && hasUsername {
+		f.accounts = append(f.accounts, &metadataproto.Account{
+			Username: []byte(username),
+			Email:    []byte(email),
+			Source:   usernameSource,
+		})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanMultilineRecord numbers what the fields of one record yield, so entities, credentials and accounts stay grouped.
+func (f *Fragments) ScanMultilineRecord(fields []RecordField) {
+	f.records++
+	sourcedCount, credentialCount, accountCount := len(f.sourced), len(f.credentials), len(f.accounts)
+
+	f.ScanRecord(metadataproto.EntityOrigin_ENTITY_ORIGIN_RECORD, fields)
+
+	for _, entity := range f.sourced[sourcedCount:] {
+		entity.Record = f.records
+	}
+	for _, credential := range f.credentials[credentialCount:] {
+		credential.Record = f.records
+	}
+	for _, account := range f.accounts[accountCount:] {
+		account.Record = f.records
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) schema() []*metadataproto.SchemaField {
+	schema := make([]*metadataproto.SchemaField, 0, len(f.schemaCount))
+
+	for path, count := range f.schemaCount {
+		schema = append(schema, &metadataproto.SchemaField{Path: path, Count: count})
+	}
+
+	sort.Slice(schema, func(i, j int) bool {
+		return schema[i].Path < schema[j].Path
+	})
+
+	return schema
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) Metadata(id string) *metadataproto.Metadata {
+	metadata := &metadataproto.Metadata{
+		Id:              id,
+		Emails:          utils.ConvertToByteSlices(f.emails),
+		Ips:             utils.ConvertToByteSlices(f.ips),
+		Domains:         utils.ConvertToByteSlices(f.domains),
+		Cards:           f.cards,
+		Ibans:           f.ibans,
+		Hashes:          SortedHashCounts(f.hashCounts),
+		Secrets:         f.secrets,
+		Credentials:     f.credentials,
+		Accounts:        f.accounts,
+		SourcedEntities: f.sourced,
+		Schema:          f.schema(),
+	}
+
+	ips := slices.Clip(f.ips)
+	for _, entity := range f.sourced {
+		if entity.Type == constants.EntityTypeIp {
+			ips = append(ips, string(entity.Value))
+		}
+	}
+	metadata.IpInfos = IpInfos(ips, f.extractOpts.IpDatabases)
+
+	if len(f.cookies) > 0 || len(f.autofill) > 0 {
+		metadata.BrowserArtifacts = &metadataproto.BrowserArtifacts{Cookies: f.cookies, Autofill: f.autofill}
+	}
+
+	if f.extractOpts.PrivacyMode == constants.PrivacyModeHmac {
+		Pseudonymize(metadata, f.extractOpts.HmacKey)
+	}
+
+	return metadata
+}
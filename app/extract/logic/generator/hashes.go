@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isHexDigest(candidate string) bool {
+	var hasDigit, hasLetter bool
+
+	for _, r := range candidate {
+		if r >= '0' && r <= '9' {
+			hasDigit = true
+		} else {
+			hasLetter = true
+		}
+	}
+
+	return hasDigit && hasLetter
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func countHash(counts map[string]*metadataproto.HashCount, hashType constants.HexHashType) {
+	key := hashType.Algorithm + "/" + hashType.Confidence.String()
+
+	if count, ok := counts[key]; ok {
+		count.Count++
+		return
+	}
+
+	counts[key] = &metadataproto.HashCount{
+		Algorithm:    hashType.Algorithm,
+		Confidence:   hashType.Confidence,
+		Count:        1,
+		Alternatives: hashType.Alternatives,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func CountHashes(line string, counts map[string]*metadataproto.HashCount) {
+	masked := []byte(line)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// pwdump records (LM & NT digests)
+	//
+	for _, loc := range constants.PwdumpPattern.FindAllStringSubmatchIndex(line, -1) {
+		if !strings.EqualFold(line[loc[2]:loc[3]], constants.EmptyLmHash) {
+			countHash(counts, constants.HexHashType{
+				Algorithm:  "lm",
+				Confidence: metadataproto.HashConfidence_HASH_CONFIDENCE_CERTAIN,
+			})
+		}
+
+		countHash(counts, constants.HexHashType{
+			Algorithm:  "ntlm",
+			Confidence: metadataproto.HashConfidence_HASH_CONFIDENCE_CERTAIN,
+		})
+
+		for i := loc[0]; i < loc[1]; i++ {
+			masked[i] = ' '
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Self-describing formats, blanked out afterward so their digests are not counted twice as raw hex
+	//
+	for _, format := range constants.HashFormats {
+		for _, loc := range format.Pattern.FindAllStringIndex(string(masked), -1) {
+			countHash(counts, constants.HexHashType{
+				Algorithm:  format.Algorithm,
+				Confidence: metadataproto.HashConfidence_HASH_CONFIDENCE_CERTAIN,
+			})
+
+			for i := loc[0]; i < loc[1]; i++ {
+				masked[i] = ' '
+			}
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Raw hex digests, classified by length
+	//
+	ntlmContext := constants.NtlmContextPattern.MatchString(line)
+
+	for _, candidate := range constants.HexHashPattern.FindAllString(string(masked), -1) {
+		hashType, ok := constants.HexHashLengths[len(candidate)]
+		if !ok || !isHexDigest(candidate) {
+			continue
+		}
+
+		if len(candidate) == 32 && ntlmContext {
+			hashType = constants.HexHashType{
+				Algorithm:    "ntlm",
+				Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_LIKELY,
+				Alternatives: []string{"md5", "lm"},
+			}
+		}
+
+		countHash(counts, hashType)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func SortedHashCounts(counts map[string]*metadataproto.HashCount) []*metadataproto.HashCount {
+	result := make([]*metadataproto.HashCount, 0, len(counts))
+
+	for _, count := range counts {
+		result = append(result, count)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count == result[j].Count {
+			if result[i].Algorithm == result[j].Algorithm {
+				return result[i].Confidence < result[j].Confidence
+			}
+			return strings.Compare(result[i].Algorithm, result[j].Algorithm) < 0
+		}
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/mmdb"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LoadIpDenylist reads the --ip-denylist file, one IP or CIDR network per line.
+func LoadIpDenylist(path string) ([]netip.Prefix, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		var msg = fmt.Sprintf("Failed to read IP denylist %s: %v", path, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	var (
+		denylist []netip.Prefix
+		scanner  = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for number := 1; scanner.Scan(); number++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(line)
+		if err != nil {
+			ip, ipErr := netip.ParseAddr(line)
+			if ipErr != nil {
+				var msg = fmt.Sprintf("Invalid IP or network %q on line %d of %s: %v", line, number, path, err)
+				logger.Logger.Error().Msg(msg)
+
+				return nil, fmt.Errorf(msg)
+			}
+			prefix = netip.PrefixFrom(ip.Unmap(), ip.Unmap().BitLen())
+		}
+
+		denylist = append(denylist, prefix.Masked())
+	}
+
+	return denylist, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ClassifyIp tells the special-purpose IPs apart from public ones, see IpClassRanges.
+func ClassifyIp(ip netip.Addr) metadataproto.IpClass {
+	ip = ip.Unmap()
+
+	for _, ipRange := range constants.IpClassRanges {
+		if ipRange.Prefix.Contains(ip) {
+			return ipRange.Class
+		}
+	}
+
+	return metadataproto.IpClass_IP_CLASS_PUBLIC
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IpInfos classifies the distinct IPs of the part and looks the public ones up in the --mmdb databases, the first
+// database knowing a field winning.
+func IpInfos(ips []string, databases []*mmdb.Reader) []*metadataproto.IpInfo {
+	var (
+		infos []*metadataproto.IpInfo
+		seen  = make(map[string]bool)
+	)
+
+	for _, value := range ips {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+
+		ip, err := netip.ParseAddr(value)
+		if err != nil {
+			continue
+		}
+
+		info := &metadataproto.IpInfo{Ip: []byte(value), Class: ClassifyIp(ip)}
+		infos = append(infos, info)
+
+		if info.Class != metadataproto.IpClass_IP_CLASS_PUBLIC {
+			continue
+		}
+
+		for _, database := range databases {
+			record, err := database.Lookup(ip)
+			if err != nil {
+				logger.Logger.Debug().Msgf("Failed to look %s up in %s: %v", value, database.Path, err)
+				continue
+			}
+
+			if info.Country == "" {
+				info.Country, _ = mmdb.Path(record, "country", "iso_code").(string)
+			}
+			if info.Country == "" {
+				info.Country, _ = mmdb.Path(record, "registered_country", "iso_code").(string)
+			}
+			if info.Asn == 0 {
+				info.Asn = uint32(mmdb.AsUint(mmdb.Path(record, "autonomous_system_number")))
+			}
+			if info.Organization == "" {
+				info.Organization, _ = mmdb.Path(record, "autonomous_system_organization").(string)
+			}
+		}
+	}
+
+	return infos
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// filterIps drops, in place, the IPs found in text that --drop-ip-classes or --ip-denylist leave out.
+func (f *Fragments) filterIps(text string, ips []string) []string {
+	if len(ips) == 0 {
+		return ips
+	}
+
+	var (
+		kept = ips[:0]
+		from int
+	)
+
+	for _, value := range ips {
+		at := strings.Index(text[from:], value)
+		if at >= 0 {
+			at += from
+			from = at + len(value)
+		}
+
+		if f.extractOpts.DropVersionIps && at >= 0 &&
+			constants.IpVersionContextPattern.MatchString(text[max(0, at-constants.IpVersionContextLength):at]) {
+			continue
+		}
+
+		if f.keepIp(value) {
+			kept = append(kept, value)
+		}
+	}
+
+	return kept
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// keepIp tells whether the IP is out of the --drop-ip-classes classes and --ip-denylist networks.
+func (f *Fragments) keepIp(value string) bool {
+	if len(f.extractOpts.DroppedIpClasses) == 0 && len(f.extractOpts.IpDenylist) == 0 {
+		return true
+	}
+
+	ip, err := netip.ParseAddr(value)
+	if err != nil {
+		return true
+	}
+	ip = ip.Unmap()
+
+	if slices.Contains(f.extractOpts.DroppedIpClasses, ClassifyIp(ip)) {
+		return false
+	}
+
+	for _, prefix := range f.extractOpts.IpDenylist {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
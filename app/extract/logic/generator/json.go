@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var utf8Bom = []byte("\xef\xbb\xbf")
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsJsonSample(sample []byte) (isJson bool, isArray bool) {
+	sample = bytes.TrimLeft(bytes.TrimPrefix(sample, utf8Bom), " \t\r\n")
+
+	if len(sample) == 0 {
+		return false, false
+	}
+
+	switch sample[0] {
+	case '{':
+		return true, false
+	case '[':
+		// a JSON array of documents, not a "[date] message" log line
+		if rest := bytes.TrimLeft(sample[1:], " \t\r\n"); len(rest) > 0 && rest[0] == '{' {
+			return true, true
+		}
+	}
+
+	return false, false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func flattenJson(path string, value interface{}, fields []RecordField, dictionary []constants.ColumnRule, key string) []RecordField {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for k := range typed {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+
+			fields = flattenJson(childPath, typed[k], fields, dictionary, k)
+		}
+
+	case []interface{}:
+		// array items share their parent path, so the schema does not grow with the array length
+		for _, item := range typed {
+			fields = flattenJson(path, item, fields, dictionary, key)
+		}
+
+	case string:
+		fields = append(fields, RecordField{Source: path, Type: ColumnType(key, dictionary), Value: typed})
+
+	case json.Number:
+		fields = append(fields, RecordField{Source: path, Type: ColumnType(key, dictionary), Value: typed.String()})
+	}
+
+	return fields
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) scanJsonDocument(document interface{}) {
+	if text, ok := document.(string); ok {
+		// bare string in an array or stream
+		f.ScanText(text)
+		return
+	}
+
+	fields := flattenJson("", document, nil, f.extractOpts.ColumnDictionary, "")
+
+	for _, field := range fields {
+		if field.Type != "" {
+			f.ScanText(field.Value)
+		} else {
+			f.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_JSON_FIELD, field.Source, field.Value)
+		}
+	}
+
+	f.ScanRecord(metadataproto.EntityOrigin_ENTITY_ORIGIN_JSON_FIELD, fields)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanJson streams the documents of a JSON array or NDJSON file. Only a first document failing to parse is an
+// error, later malformed ones being counted, their text scanned as plain lines and the stream resynced at the next line.
+func (f *Fragments) ScanJson(reader *bufio.Reader, isArray bool) error {
+	if bom, _ := reader.Peek(len(utf8Bom)); bytes.Equal(bom, utf8Bom) {
+		_, _ = reader.Discard(len(utf8Bom))
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	var documents int
+
+	decode := func() error {
+		var document interface{}
+		if err := decoder.Decode(&document); err != nil {
+			if documents == 0 {
+				return fmt.Errorf("invalid JSON document at offset %d: %v", decoder.InputOffset(), err)
+			}
+
+			f.invalidDocuments++
+			f.scanJsonLines(bufio.NewReader(io.MultiReader(decoder.Buffered(), reader)))
+
+			return io.EOF
+		}
+
+		documents++
+		f.scanJsonDocument(document)
+
+		return nil
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// A top-level array (Elasticsearch/Mongo exports) is streamed item by item instead of being decoded at once
+	//
+	if isArray {
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("invalid JSON array: %v", err)
+		}
+
+		for decoder.More() {
+			if err := decode(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("invalid JSON array end: %v", err)
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// NDJSON, or pretty-printed documents one after another
+	//
+	for decoder.More() {
+		if err := decode(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanJsonLines takes over once a document failed to parse, from that document on: lines holding a whole document
+// (NDJSON, one document per line arrays) are still scanned field by field, the other ones as plain text, so that no
+// entity is lost.
+func (f *Fragments) scanJsonLines(reader *bufio.Reader) {
+	// the failed document, already counted, starts the first non-blank line
+	first := true
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		trimmed := strings.TrimRight(strings.TrimSpace(line), ",")
+		switch {
+		case trimmed == "" || trimmed == "[" || trimmed == "]":
+
+		case !first && strings.HasPrefix(trimmed, "{") && f.scanJsonLine(trimmed):
+
+		default:
+			if !first && strings.HasPrefix(trimmed, "{") {
+				f.invalidDocuments++
+			}
+			f.ScanText(line)
+			first = false
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) scanJsonLine(line string) bool {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return false
+	}
+
+	f.scanJsonDocument(document)
+
+	return true
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/mailbox"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsMailPath tells whether the part is an mbox or a message, e.g. the "headers.eml" prepare writes.
+func IsMailPath(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+
+	return extension == constants.MailboxExtension || extension == constants.MessageExtension
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanMail scans each message of an mbox, or the single message of an .eml part.
+func scanMail(reader *bufio.Reader, fragments *Fragments, path string) {
+	if strings.ToLower(filepath.Ext(path)) != constants.MailboxExtension {
+		scanMessage(reader, fragments)
+		return
+	}
+
+	if err := mailbox.SplitMbox(reader, func(message []byte) error {
+		scanMessage(bufio.NewReader(bytes.NewReader(message)), fragments)
+		return nil
+	}); err != nil {
+		logger.Logger.Warn().Err(err).Msgf("Error reading mailbox: %s", path)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanMessage sources the addresses and relays of the headers from the header name, the headers of a message
+// making one record, dates its Date header, then scans the decoded text parts as plain lines.
+func scanMessage(reader *bufio.Reader, fragments *Fragments) {
+	message, err := mail.ReadMessage(reader)
+	if err != nil {
+		logger.Logger.Debug().Msgf("Falling back to line extraction on invalid message: %v", err)
+		scanLines(reader, fragments, nil)
+
+		return
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Headers
+	//
+	fragments.records++
+	sourcedCount := len(fragments.sourced)
+
+	for _, name := range constants.MailAddressHeaders {
+		source := strings.ToLower(name)
+
+		for _, value := range message.Header[textproto.CanonicalMIMEHeaderKey(name)] {
+			addresses, err := (&mail.AddressParser{WordDecoder: mailbox.WordDecoder}).ParseList(value)
+			if err != nil {
+				fragments.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_MAIL_HEADER, source, value)
+				continue
+			}
+
+			for _, address := range addresses {
+				fragments.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_MAIL_HEADER, source, address.Address)
+			}
+		}
+	}
+
+	for _, name := range constants.MailRelayHeaders {
+		source := strings.ToLower(name)
+
+		for _, value := range message.Header[textproto.CanonicalMIMEHeaderKey(name)] {
+			fragments.ScanSourcedText(metadataproto.EntityOrigin_ENTITY_ORIGIN_MAIL_HEADER, source, value)
+		}
+	}
+
+	fragments.dates.ScanText(message.Header.Get("Date"))
+
+	for _, entity := range fragments.sourced[sourcedCount:] {
+		entity.Record = fragments.records
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Body, empty for the headers files of prepare which decodes parts beforehand
+	//
+	if err := mailbox.WalkParts(message.Header, message.Body, func(part mailbox.Part) error {
+		switch {
+		case part.MediaType == "message/rfc822":
+			scanMessage(bufio.NewReader(part.Body), fragments)
+		case part.MediaType == "text/html":
+			scanMarkup(part.Text(), fragments, false)
+		case part.IsText():
+			scanLines(bufio.NewReader(part.Text()), fragments, nil)
+		}
+
+		return nil
+	}); err != nil {
+		logger.Logger.Debug().Msgf("Error decoding message parts: %v", err)
+	}
+
+	// whatever the walk left unread still counts in the stats
+	_, _ = io.Copy(io.Discard, message.Body)
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+}
@@ -0,0 +1,309 @@
+package generator
+
+import (
+	"bufio"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"html"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var partSuffixRegex = regexp.MustCompile(`\.part\d+$`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// markupScanner splits markup into visible text, scanned line by line afterward, and attributes, scanned on the
+// fly and sourced from "tag.attribute".
+type markupScanner struct {
+	fragments *Fragments
+	isXml     bool
+	text      strings.Builder
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// partExtension is the extension of the file a part comes from, ".html" for "index.html.part3".
+func partExtension(path string) string {
+	if match := partSuffixRegex.FindStringIndex(path); match != nil {
+		path = path[:match[0]]
+	}
+
+	return strings.ToLower(filepath.Ext(path))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkupKind tells whether the part is HTML or XML, by its extension.
+func MarkupKind(path string) (isMarkup bool, isXml bool) {
+	extension := partExtension(path)
+
+	if slices.Contains(constants.MarkupXmlExtensions, extension) {
+		return true, true
+	}
+
+	return slices.Contains(constants.MarkupHtmlExtensions, extension), false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func scanMarkup(reader io.Reader, fragments *Fragments, isXml bool) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("Error reading markup")
+	}
+
+	scanner := &markupScanner{fragments: fragments, isXml: isXml}
+	scanner.walk(string(data))
+
+	scanLines(bufio.NewReader(strings.NewReader(scanner.text.String())), fragments, nil)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *markupScanner) walk(data string) {
+	for len(data) > 0 {
+		start := strings.IndexByte(data, '<')
+		if start < 0 {
+			s.text.WriteString(html.UnescapeString(data))
+			return
+		}
+
+		s.text.WriteString(html.UnescapeString(data[:start]))
+		data = data[start:]
+
+		switch {
+		case strings.HasPrefix(data, "<!--"):
+			data = skipPast(data, "-->")
+
+		case strings.HasPrefix(data, "<![CDATA["):
+			end := strings.Index(data, "]]>")
+			if end < 0 {
+				end = len(data)
+			}
+			s.text.WriteString(data[len("<![CDATA["):end])
+			data = skipPast(data, "]]>")
+
+		case strings.HasPrefix(data, "<!"), strings.HasPrefix(data, "<?"):
+			data = skipPast(data, ">")
+
+		case strings.HasPrefix(data, "</"):
+			name, _ := tagName(data[2:])
+			s.boundary(name)
+			data = skipPast(data, ">")
+
+		case len(data) > 1 && isTagStart(data[1]):
+			data = s.startTag(data)
+
+		default:
+			s.text.WriteByte('<')
+			data = data[1:]
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// startTag scans the attributes of the tag at the start of data, and skips the content of raw tags, returning what
+// follows.
+func (s *markupScanner) startTag(data string) string {
+	name, rest := tagName(data[1:])
+	s.boundary(name)
+
+	attributes := make(map[string]string)
+
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n/")
+		if rest == "" || rest[0] == '>' {
+			break
+		}
+
+		var key, value string
+		key, value, rest = nextAttribute(rest)
+
+		attributes[key] = value
+		s.attribute(name, key, value)
+	}
+
+	rest = strings.TrimPrefix(rest, ">")
+
+	selfClosing := strings.HasSuffix(data[:len(data)-len(rest)], "/>")
+	if s.isXml || selfClosing || !slices.Contains(constants.MarkupRawTags, name) {
+		return rest
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Scripts and styles
+	//
+	end := strings.Index(strings.ToLower(rest), "</"+name)
+	if end < 0 {
+		end = len(rest)
+	}
+	content := rest[:end]
+
+	switch {
+	case name == "script" && strings.Contains(strings.ToLower(attributes["type"]), "json"):
+		// JSON-LD and such data islands
+		s.text.WriteString(content)
+		s.text.WriteByte('\n')
+
+	case name == "script":
+		for _, line := range strings.Split(content, "\n") {
+			s.fragments.secrets = append(s.fragments.secrets, s.fragments.secretScanner.ScanLine(line)...)
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return skipPast(rest[end:], ">")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// boundary separates the visible text around tags, inline HTML tags joining what they split, e.g. obfuscated
+// "john<span>@</span>example.com".
+func (s *markupScanner) boundary(name string) {
+	switch {
+	case s.isXml, slices.Contains(constants.MarkupBlockTags, name):
+		s.text.WriteByte('\n')
+	case slices.Contains(constants.MarkupCellTags, name):
+		s.text.WriteByte('\t')
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *markupScanner) attribute(tag string, key string, value string) {
+	source := tag + "." + key
+	origin := metadataproto.EntityOrigin_ENTITY_ORIGIN_MARKUP_ATTRIBUTE
+
+	switch {
+	case value == "":
+
+	case slices.Contains(constants.MarkupLinkAttributes, key):
+		s.link(source, value)
+
+	case s.isXml:
+		if key != "xmlns" && !strings.HasPrefix(key, "xmlns:") && !strings.HasPrefix(key, "xsi:") {
+			s.fragments.ScanSourcedText(origin, source, value)
+		}
+
+	case slices.Contains(constants.MarkupTextAttributes, key), strings.HasPrefix(key, "data-"):
+		s.fragments.ScanSourcedText(origin, source, value)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// link harvests absolute URLs as "url" entities and the addresses of "mailto:" links, percent-decoded.
+func (s *markupScanner) link(source string, value string) {
+	origin := metadataproto.EntityOrigin_ENTITY_ORIGIN_MARKUP_ATTRIBUTE
+	value = strings.TrimSpace(value)
+
+	scheme, rest, hasScheme := strings.Cut(value, ":")
+	scheme = strings.ToLower(scheme)
+
+	switch {
+	case hasScheme && scheme == "mailto":
+		if decoded, err := url.PathUnescape(rest); err == nil {
+			rest = decoded
+		}
+		// "mailto:a@b.com,c@d.com?cc=e@f.com&subject=..."
+		s.fragments.ScanSourcedText(origin, source, strings.NewReplacer("?", " ", "&", " ", ",", " ").Replace(rest))
+
+	case hasScheme && slices.Contains(constants.MarkupUrlSchemes, scheme), strings.HasPrefix(value, "//"):
+		s.fragments.sourced = append(s.fragments.sourced, &metadataproto.SourcedEntity{
+			Type:   constants.EntityTypeUrl,
+			Value:  []byte(value),
+			Origin: origin,
+			Source: source,
+		})
+		s.fragments.ScanSourcedText(origin, source, value)
+
+	case hasScheme && !strings.ContainsAny(scheme, "/?#"):
+		// javascript:, data:, tel: and such
+
+	default:
+		// relative links may still carry emails in their query
+		s.fragments.ScanSourcedText(origin, source, value)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func isTagStart(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tagName returns the lowercased name at the start of data, namespace prefix included, and what follows.
+func tagName(data string) (string, string) {
+	end := strings.IndexAny(data, " \t\r\n/>")
+	if end < 0 {
+		end = len(data)
+	}
+
+	return strings.ToLower(data[:end]), data[end:]
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// nextAttribute parses `key="value"`, `key='value'`, `key=value` or `key` at the start of data, the value being
+// unescaped.
+func nextAttribute(data string) (string, string, string) {
+	end := strings.IndexAny(data, " \t\r\n/>=")
+	if end < 0 {
+		end = len(data)
+	}
+	if end == 0 {
+		// stray "=" or quote
+		end = 1
+	}
+
+	key := strings.ToLower(data[:end])
+	data = strings.TrimLeft(data[end:], " \t\r\n")
+
+	if !strings.HasPrefix(data, "=") {
+		return key, "", data
+	}
+	data = strings.TrimLeft(data[1:], " \t\r\n")
+
+	if data != "" && (data[0] == '"' || data[0] == '\'') {
+		closing := strings.IndexByte(data[1:], data[0])
+		if closing < 0 {
+			return key, html.UnescapeString(data[1:]), ""
+		}
+
+		return key, html.UnescapeString(data[1 : closing+1]), data[closing+2:]
+	}
+
+	end = strings.IndexAny(data, " \t\r\n>")
+	if end < 0 {
+		end = len(data)
+	}
+
+	return key, html.UnescapeString(data[:end]), data[end:]
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func skipPast(data string, marker string) string {
+	end := strings.Index(data, marker)
+	if end < 0 {
+		return ""
+	}
+
+	return data[end+len(marker):]
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/noise"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// filterNoise drops the domains and emails matching the --noise-filter rules of the bucket, returning how many
+// entities each rule removed. Credentials and accounts go with their email, cookies with their domain.
+func (f *Fragments) filterNoise(bucket infoproto.Bucket) []*metadataproto.NamedCount {
+	filter := f.extractOpts.NoiseFilters[bucket]
+	if filter == nil {
+		return nil
+	}
+
+	removed := make(map[string]uint64)
+
+	f.emails = dropNoise(filter, removed, constants.EntityTypeEmail, f.emails,
+		func(email string) string { return email },
+	)
+	f.domains = dropNoise(filter, removed, constants.EntityTypeDomain, f.domains,
+		func(domain string) string { return domain },
+	)
+	f.credentials = dropNoise(filter, removed, constants.EntityTypeEmail, f.credentials,
+		func(credential *metadataproto.Credential) string { return string(credential.Email) },
+	)
+	f.accounts = dropNoise(filter, removed, constants.EntityTypeEmail, f.accounts,
+		func(account *metadataproto.Account) string { return string(account.Email) },
+	)
+	// cookies set for a domain and its subdomains start with a dot
+	f.cookies = dropNoise(filter, removed, constants.EntityTypeDomain, f.cookies,
+		func(cookie *metadataproto.Cookie) string { return strings.TrimPrefix(string(cookie.Domain), ".") },
+	)
+
+	kept := f.sourced[:0]
+	for _, entity := range f.sourced {
+		if entity.Type == constants.EntityTypeEmail || entity.Type == constants.EntityTypeDomain {
+			if name, ok := filter.Match(entity.Type, string(entity.Value)); ok {
+				removed[name]++
+				continue
+			}
+		}
+		kept = append(kept, entity)
+	}
+	f.sourced = kept
+
+	return sortedNamedCounts(removed)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dropNoise keeps the items whose value, e.g. the email of a credential, matches no rule. Empty values always do.
+func dropNoise[T any](
+	filter *noise.Filter,
+	removed map[string]uint64,
+	entityType string,
+	items []T,
+	value func(T) string,
+) []T {
+	kept := items[:0]
+
+	for _, item := range items {
+		if value := value(item); value != "" {
+			if name, ok := filter.Match(entityType, value); ok {
+				removed[name]++
+				continue
+			}
+		}
+		kept = append(kept, item)
+	}
+
+	return kept
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/segmentio/fasthash/fnv1a"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OptionsFingerprint hashes the extract options changing what is emitted, but the privacy ones, see Privacy, so that
+// extract --incremental never mixes items extracted under different ones.
+func OptionsFingerprint(extractOpts structs.ExtractOptsStruct) string {
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Column rules and databases in order, the first one matching or knowing a field winning
+	//
+	ordered := []string{"password-mode " + extractOpts.PasswordMode, "records " + extractOpts.RecordMode}
+	if extractOpts.RecordStart != nil {
+		ordered = append(ordered, "record-start "+extractOpts.RecordStart.String())
+	}
+
+	for _, rule := range extractOpts.ColumnDictionary {
+		ordered = append(ordered, "column "+rule.Type+" "+rule.Pattern.String())
+	}
+
+	for _, database := range extractOpts.IpDatabases {
+		ordered = append(ordered, fmt.Sprintf("mmdb %s %d", database.DatabaseType, database.BuildEpoch))
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Filters are sets, sorted
+	//
+	var filters []string
+
+	for _, class := range extractOpts.DroppedIpClasses {
+		filters = append(filters, "drop-ip-class "+class.String())
+	}
+	if extractOpts.DropVersionIps {
+		filters = append(filters, "drop-ip-class version")
+	}
+	for _, prefix := range extractOpts.IpDenylist {
+		filters = append(filters, "ip-denylist "+prefix.String())
+	}
+
+	for bucket, filter := range extractOpts.NoiseFilters {
+		for _, name := range filter.Names() {
+			filters = append(filters, "noise-filter "+bucket.String()+" "+name)
+		}
+	}
+
+	slices.Sort(filters)
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return fmt.Sprintf("%016x", fnv1a.HashString64(strings.Join(append(ordered, filters...), "\n")))
+}
@@ -3,9 +3,9 @@ package generator
 import (
 	"bufio"
 	"fmt"
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
 	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
-	"github.com/Rom1-J/preprocessor/pkg/utils"
 	infoproto "github.com/Rom1-J/preprocessor/proto/info"
 	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
 	"io"
@@ -15,77 +15,222 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func Extract(filePath string, metadataInfo *infoproto.MetadataInfo) (*metadataproto.Metadata, error) {
-	logger.Logger.Trace().Msgf("Extract starting on: %s", metadataInfo.Id)
+// PartOpener opens a part from the beginning, a data file or an entry of a kept archive.
+type PartOpener func() (io.ReadCloser, error)
 
-	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-	//
-	// Initializing fragments
-	//
-	var (
-		emails  []string
-		ips     []string
-		domains []string
-	)
-	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func FileOpener(path string) PartOpener {
+	return func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func scanLines(reader *bufio.Reader, fragments *Fragments, csvLayout *CsvLayout) {
+	isHeader := csvLayout != nil
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			logger.Logger.Warn().Err(err).Msgf("Error reading line: %s: %s", line, err)
+			break
+		}
+
+		if line != "" {
+			switch {
+			case isHeader:
+				fragments.ScanText(line)
+				isHeader = false
+
+			case csvLayout != nil:
+				fragments.ScanText(line)
+				fragments.ScanRecord(metadataproto.EntityOrigin_ENTITY_ORIGIN_CSV_COLUMN, csvLayout.Record(line))
+
+			default:
+				fragments.ScanLine(line)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// partMetadata tags the item with what it was extracted from and by, see extract --incremental, and its stats.
+func partMetadata(
+	fragments *Fragments,
+	metadataInfo *infoproto.MetadataInfo,
+	stats *metadataproto.PartStats,
+) *metadataproto.Metadata {
+	stats.Filtered = fragments.filterNoise(metadataInfo.Bucket)
+	stats.Matches = fragments.matches()
+	stats.Records = fragments.records
+	stats.InvalidDocuments = fragments.invalidDocuments
+	stats.EarliestDate = fragments.dates.Earliest
+	stats.LatestDate = fragments.dates.Latest
+
+	metadata := fragments.Metadata(metadataInfo.Id)
+	metadata.PartHash = metadataInfo.Simhash
+	metadata.ExtractorVersion = constants.ExtractorVersion
+	metadata.Stats = stats
+
+	return metadata
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func Extract(
+	open PartOpener,
+	metadataInfo *infoproto.MetadataInfo,
+	extractOpts structs.ExtractOptsStruct,
+) (*metadataproto.Metadata, error) {
+	logger.Logger.Trace().Msgf("Extract starting on: %s", metadataInfo.Id)
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
 	// Initializing file reader
 	//
-	file, err := os.Open(filePath)
+	file, err := open()
 	if err != nil {
 		logger.Logger.Error().Msgf("Failed to open file: %v", err)
 
 		return nil, fmt.Errorf("Failed to open file: %v", err)
 	}
 
-	defer func(file *os.File) {
+	defer func() {
 		if err := file.Close(); err != nil {
 			return
 		}
-	}(file)
+	}()
+
+	lineStats := NewLineStats(file)
+	reader := bufio.NewReaderSize(lineStats, constants.ExtractReadBufferSize)
+	sample, _ := reader.Peek(constants.CsvSniffSize)
 
-	reader := bufio.NewReader(file)
+	fragments := NewFragments(extractOpts)
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Generate metadata collection
+	// Mailboxes and messages, headers being sourced apart from body matches
 	//
-	for {
-		line, err := reader.ReadString('\n')
-		if err == io.EOF {
-			break
+	if IsMailPath(string(metadataInfo.Path)) {
+		scanMail(reader, fragments, string(metadataInfo.Path))
+		_, _ = io.Copy(io.Discard, reader)
+
+		logger.Logger.Trace().Msgf("Extract finished on: %s (mail)", metadataInfo.Id)
+
+		return partMetadata(fragments, metadataInfo, lineStats.Stats()), nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// HTML and XML, attributes being sourced apart from visible text matches
+	//
+	if isMarkup, isXml := MarkupKind(string(metadataInfo.Path)); isMarkup {
+		scanMarkup(reader, fragments, isXml)
+
+		logger.Logger.Trace().Msgf("Extract finished on: %s (markup)", metadataInfo.Id)
+
+		return partMetadata(fragments, metadataInfo, lineStats.Stats()), nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// JSON documents, falling back to plain lines when the file is not valid JSON after all
+	//
+	if isJson, isArray := IsJsonSample(sample); isJson {
+		err := fragments.ScanJson(reader, isArray)
+		if err == nil {
+			// trailing whitespace still counts in the stats
+			_, _ = io.Copy(io.Discard, reader)
+
+			if fragments.invalidDocuments > 0 {
+				logger.Logger.Warn().Msgf("Skipped %d malformed JSON documents in %s", fragments.invalidDocuments, metadataInfo.Id)
+			}
+
+			logger.Logger.Trace().Msgf("Extract finished on: %s (json)", metadataInfo.Id)
+
+			return partMetadata(fragments, metadataInfo, lineStats.Stats()), nil
 		}
-		if err != nil {
-			logger.Logger.Warn().Err(err).Msgf("Error reading line: %s: %s", line, err)
-			continue
+
+		logger.Logger.Debug().Msgf("Falling back to line extraction on %s: %v", metadataInfo.Id, err)
+
+		// reopening rewinds files and archive entries alike
+		_ = file.Close()
+		if file, err = open(); err != nil {
+			logger.Logger.Error().Msgf("Failed to rewind file: %v", err)
+
+			return nil, fmt.Errorf("Failed to rewind file: %v", err)
 		}
-		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-		//
-		// Extract fragments
-		//
-		emails = append(emails, constants.EmailPattern.FindAllString(line, -1)...)
-		ips = append(ips, constants.IpPattern.FindAllString(line, -1)...)
-		domains = append(domains, constants.DomainPattern.FindAllString(line, -1)...)
-		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+		lineStats = NewLineStats(file)
+		reader.Reset(lineStats)
+		sample, _ = reader.Peek(constants.CsvSniffSize)
+		fragments = NewFragments(extractOpts)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Browser artifacts of stealer logs, cookie files being tab-separated they go before CSV sniffing
+	//
+	switch {
+	case IsCookieSample(sample):
+		scanCookies(reader, fragments)
+
+		logger.Logger.Trace().Msgf("Extract finished on: %s (cookies)", metadataInfo.Id)
+
+		return partMetadata(fragments, metadataInfo, lineStats.Stats()), nil
+
+	case IsAutofillSample(sample):
+		scanAutofill(reader, fragments)
+
+		logger.Logger.Trace().Msgf("Extract finished on: %s (autofill)", metadataInfo.Id)
+
+		return partMetadata(fragments, metadataInfo, lineStats.Stats()), nil
 	}
-	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Returning metadata
+	// CSV/TSV rows, multi-line records or plain lines
 	//
-	metadata := &metadataproto.Metadata{
-		Id:      metadataInfo.Id,
-		Emails:  utils.ConvertToByteSlices(emails),
-		Ips:     utils.ConvertToByteSlices(ips),
-		Domains: utils.ConvertToByteSlices(domains),
+	csvLayout := SniffCsvLayout(sample, extractOpts.ColumnDictionary)
+	recordLayout := NewRecordLayout(extractOpts.RecordMode, extractOpts.RecordStart)
+
+	switch {
+	case csvLayout != nil:
+		logger.Logger.Debug().Msgf("CSV layout detected on %s: %q %v", metadataInfo.Id, csvLayout.Delimiter, csvLayout.Header)
+
+	case recordLayout == nil && extractOpts.RecordMode == constants.RecordModeAuto:
+		if recordLayout = SniffRecordLayout(sample); recordLayout != nil {
+			logger.Logger.Debug().Msgf("Record layout detected on %s: %+v", metadataInfo.Id, *recordLayout)
+		}
 	}
-	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	if csvLayout == nil && recordLayout != nil {
+		scanRecords(reader, fragments, recordLayout)
+	} else {
+		scanLines(reader, fragments, csvLayout)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 	logger.Logger.Trace().Msgf("Extract finished on: %s", metadataInfo.Id)
 
-	return metadata, nil
+	stats := lineStats.Stats()
+	if csvLayout != nil {
+		stats.Delimiter = string(csvLayout.Delimiter)
+	}
+
+	return partMetadata(fragments, metadataInfo, stats), nil
 }
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/app/extract/structs"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Privacy is recorded in _metadata.pb, so outputs of different modes or keys are never merged.
+func Privacy(extractOpts structs.ExtractOptsStruct) *metadataproto.Privacy {
+	privacy := &metadataproto.Privacy{KeyId: utils.KeyId(extractOpts.HmacKey)}
+
+	if extractOpts.PrivacyMode == constants.PrivacyModeHmac {
+		privacy.Mode = metadataproto.PrivacyMode_PRIVACY_MODE_HMAC
+	}
+
+	return privacy
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func hashEntities(key []byte, entityType string, values [][]byte) [][]byte {
+	for i, value := range values {
+		values[i] = utils.EntityHash(key, entityType, string(value))
+	}
+
+	return values
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Pseudonymize replaces every entity of the item by the keyed hash of its normalized form and drops the partial
+// previews, only exact-match lookups under the same key are left possible.
+func Pseudonymize(metadata *metadataproto.Metadata, key []byte) {
+	metadata.Emails = hashEntities(key, constants.EntityTypeEmail, metadata.Emails)
+	metadata.Ips = hashEntities(key, constants.EntityTypeIp, metadata.Ips)
+	metadata.Domains = hashEntities(key, constants.EntityTypeDomain, metadata.Domains)
+
+	for _, card := range metadata.Cards {
+		card.Masked = nil
+	}
+
+	for _, iban := range metadata.Ibans {
+		iban.Masked = nil
+	}
+
+	for _, secret := range metadata.Secrets {
+		secret.Preview = nil
+		if secret.JwtSubject != "" {
+			secret.JwtSubject = string(utils.KeyedHash(key, []byte(secret.JwtSubject)))
+		}
+	}
+
+	for _, credential := range metadata.Credentials {
+		credential.Email = utils.EntityHash(key, constants.EntityTypeEmail, string(credential.Email))
+	}
+
+	for _, account := range metadata.Accounts {
+		account.Username = utils.EntityHash(key, constants.EntityTypeUsername, string(account.Username))
+		account.Email = utils.EntityHash(key, constants.EntityTypeEmail, string(account.Email))
+	}
+
+	for _, entity := range metadata.SourcedEntities {
+		entity.Value = utils.EntityHash(key, entity.Type, string(entity.Value))
+	}
+
+	for _, info := range metadata.IpInfos {
+		info.Ip = utils.EntityHash(key, constants.EntityTypeIp, string(info.Ip))
+	}
+
+	for _, cookie := range metadata.GetBrowserArtifacts().GetCookies() {
+		cookie.Domain = utils.EntityHash(key, constants.EntityTypeDomain, string(cookie.Domain))
+	}
+
+	for _, field := range metadata.GetBrowserArtifacts().GetAutofill() {
+		field.Value = utils.KeyedHash(key, []byte(field.Value))
+	}
+}
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/base64"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"io"
+	"regexp"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RecordLayout splits a part into multi-line records, e.g. stealer-log "URL:/Username:/Password:" blocks, LDIF
+// entries or vCards.
+type RecordLayout struct {
+	Blank     bool           // blank lines end records
+	Separator bool           // "=====" like lines end records
+	Start     *regexp.Regexp // matching lines start a new record
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewRecordLayout builds the layout of --records, nil for line and auto modes, the latter being sniffed per part.
+func NewRecordLayout(mode string, start *regexp.Regexp) *RecordLayout {
+	switch mode {
+	case constants.RecordModeBlank:
+		return &RecordLayout{Blank: true}
+	case constants.RecordModeSeparator:
+		return &RecordLayout{Separator: true}
+	case constants.RecordModeStart:
+		return &RecordLayout{Start: start}
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SniffRecordLayout returns a layout when most sampled lines are "key: value" fields grouped in several records.
+func SniffRecordLayout(sample []byte) *RecordLayout {
+	lines := strings.Split(string(sample), "\n")
+
+	// the last line may be cut by the sample size
+	if len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+
+	var (
+		layout                      = &RecordLayout{}
+		nonBlank, fields, delimiter int
+	)
+
+	for _, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "":
+			layout.Blank = true
+			delimiter++
+			continue
+
+		case constants.RecordSeparatorPattern.MatchString(line):
+			layout.Separator = true
+			delimiter++
+			continue
+		}
+
+		nonBlank++
+
+		if layout.Start == nil {
+			for _, pattern := range constants.RecordStartPatterns {
+				if pattern.MatchString(line) {
+					layout.Start = pattern
+					break
+				}
+			}
+		}
+
+		if _, ok := recordField(line); ok || constants.RecordMarkerPattern.MatchString(line) {
+			fields++
+		}
+	}
+
+	if nonBlank == 0 || float64(fields) < constants.RecordSniffRatio*float64(nonBlank) {
+		return nil
+	}
+
+	// vCards and LDIF entries are framed by their first line, blank lines may be missing
+	if layout.Start == nil && delimiter < constants.RecordSniffMinimum {
+		return nil
+	}
+
+	return layout
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// recordField splits a "key: value" line, the key being kept as source.
+func recordField(line string) (RecordField, bool) {
+	match := constants.RecordFieldPattern.FindStringSubmatch(line)
+	if match == nil {
+		return RecordField{}, false
+	}
+
+	key, separator, value := match[1], match[2], match[3]
+
+	switch separator {
+	case ":":
+		// "https://..." is a value, not a field named "https"
+		if strings.HasPrefix(value, "//") {
+			return RecordField{}, false
+		}
+
+	case "::":
+		// LDIF base64 value
+		if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+			value = string(decoded)
+		}
+	}
+
+	return RecordField{Source: NormalizeColumnName(key), Value: value}, true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (l *RecordLayout) ends(line string) bool {
+	return (l.Blank && strings.TrimSpace(line) == "") || (l.Separator && constants.RecordSeparatorPattern.MatchString(line))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (l *RecordLayout) starts(line string) bool {
+	return l.Start != nil && l.Start.MatchString(line)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanRecords runs the extractors on every line, then on the fields of each record together.
+func scanRecords(reader *bufio.Reader, fragments *Fragments, layout *RecordLayout) {
+	var fields []RecordField
+	lines := 0
+
+	flush := func() {
+		if lines > 0 {
+			fragments.ScanMultilineRecord(fields)
+		}
+		fields, lines = fields[:0], 0
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			logger.Logger.Warn().Err(err).Msgf("Error reading line: %s: %s", line, err)
+			break
+		}
+
+		switch {
+		case line == "":
+
+		case layout.ends(line):
+			flush()
+
+		default:
+			if layout.starts(line) || lines >= constants.RecordMaxLines {
+				flush()
+			}
+			lines++
+
+			if constants.RecordMarkerPattern.MatchString(line) {
+				break
+			}
+
+			if field, ok := recordField(line); ok {
+				field.Type = ColumnType(field.Source, fragments.extractOpts.ColumnDictionary)
+				fields = append(fields, field)
+
+				fragments.ScanText(line)
+			} else {
+				fragments.ScanLine(line)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	flush()
+}
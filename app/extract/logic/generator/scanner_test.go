@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var scannerSamples = []string{
+	"john.doe@example.com 192.168.1.254 https://www.sub.example.co.uk/path",
+	"fe80::1ff:fe23:4567:890a mail.google.com:443 ::1 ::",
+	"user_name+tag@mail.example.fr;0.0.0.0|v1.2.3.4",
+	"a@b a@b.c x@y.museum ..@example.com first..last@example.com",
+	"999.1.1.1 1.2.3 1.2.3.4.5 01.02.03.04",
+	"\"id\": 12345, \"host\": \"localhost\", \"email\": \"é@exemple.fr\"",
+	"KELVIN.Ka.com long.ſite.org",
+	"",
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scannerCorpus mixes entities, near misses and separators, the same for every run.
+func scannerCorpus(lines int) []string {
+	r := rand.New(rand.NewSource(1))
+	words := []string{
+		"john.doe@example.com", "192.168.1.254", "fe80::1ff:fe23:4567:890a", "https://www.sub.example.co.uk/path",
+		"password123", "lorem", "ipsum", "2024-01-01T00:00:00Z", "v1.2.3.4", "::", "a@b", "mail.google.com:443",
+		"\"id\":", "12345", "localhost", "user_name+tag@mail.example.fr", "0.0.0.0", "...", "--", "é",
+	}
+
+	corpus := make([]string, 0, lines)
+	for i := 0; i < lines; i++ {
+		var line strings.Builder
+		for j := 0; j < 4+r.Intn(12); j++ {
+			line.WriteString(words[r.Intn(len(words))])
+			line.WriteByte(" :;,|\t"[r.Intn(6)])
+		}
+		corpus = append(corpus, line.String())
+	}
+
+	return corpus
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func corpusSize(lines []string) int64 {
+	var size int64
+	for _, line := range lines {
+		size += int64(len(line))
+	}
+
+	return size
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func checkScannerMatchesRegexes(t *testing.T, scanner *EntityScanner, line string) {
+	emails, ips, domains := scanner.Scan(line)
+
+	for _, found := range []struct {
+		name     string
+		got      []string
+		expected []string
+	}{
+		{"emails", emails, constants.EmailPattern.FindAllString(line, -1)},
+		{"ips", ips, constants.IpPattern.FindAllString(line, -1)},
+		{"domains", domains, constants.DomainPattern.FindAllString(line, -1)},
+	} {
+		if !slices.Equal(found.got, found.expected) {
+			t.Errorf("Scan(%q) %s = %q, regex found %q", line, found.name, found.got, found.expected)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestEntityScannerMatchesRegexes(t *testing.T) {
+	scanner := NewEntityScanner()
+
+	for _, line := range append(slices.Clone(scannerSamples), scannerCorpus(5_000)...) {
+		checkScannerMatchesRegexes(t, scanner, line)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func FuzzEntityScannerMatchesRegexes(f *testing.F) {
+	for _, line := range scannerSamples {
+		f.Add(line)
+	}
+
+	scanner := NewEntityScanner()
+
+	f.Fuzz(func(t *testing.T, line string) {
+		checkScannerMatchesRegexes(t, scanner, line)
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BenchmarkEntityScanner reports the throughput of a core with -cpu 1, and of several with e.g. -cpu 4, every goroutine
+// having its own scanner.
+func BenchmarkEntityScanner(b *testing.B) {
+	lines := scannerCorpus(10_000)
+	b.SetBytes(corpusSize(lines))
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		scanner := NewEntityScanner()
+
+		for pb.Next() {
+			for _, line := range lines {
+				scanner.Scan(line)
+			}
+		}
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BenchmarkRegexes runs the three patterns the scanner replaces, for comparison.
+func BenchmarkRegexes(b *testing.B) {
+	lines := scannerCorpus(10_000)
+	b.SetBytes(corpusSize(lines))
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, line := range lines {
+				constants.EmailPattern.FindAllString(line, -1)
+				constants.IpPattern.FindAllString(line, -1)
+				constants.DomainPattern.FindAllString(line, -1)
+			}
+		}
+	})
+}
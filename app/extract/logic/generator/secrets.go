@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type SecretScanner struct {
+	hmacKey []byte
+
+	privateKeyType  string
+	privateKeyBlock strings.Builder
+	privateKeyLines int
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewSecretScanner(hmacKey []byte) *SecretScanner {
+	return &SecretScanner{hmacKey: hmacKey}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func redactSecret(secret string) []byte {
+	visible := len(secret) / 8
+	if visible > 4 {
+		visible = 4
+	}
+
+	// long secrets (JWTs, webhooks) are shortened, the preview only needs to be recognizable
+	if len(secret) > 2*visible+16 {
+		secret = secret[:visible+8] + secret[len(secret)-visible-8:]
+	}
+
+	return utils.Redact(secret, visible, visible)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func decodeJwtClaims(token string) (issuer string, subject string) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", ""
+	}
+
+	var claims struct {
+		Issuer  interface{} `json:"iss"`
+		Subject interface{} `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", ""
+	}
+
+	if value, ok := claims.Issuer.(string); ok {
+		issuer = value
+	}
+	if value, ok := claims.Subject.(string); ok {
+		subject = value
+	}
+
+	return issuer, subject
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *SecretScanner) scanPrivateKey(line string) *metadataproto.Secret {
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Open a new PEM block
+	//
+	if s.privateKeyType == "" {
+		match := constants.PrivateKeyBeginPattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil
+		}
+
+		s.privateKeyType = match[1]
+		s.privateKeyBlock.Reset()
+		s.privateKeyLines = 0
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	s.privateKeyBlock.WriteString(strings.TrimSpace(line))
+	s.privateKeyLines++
+
+	if !constants.PrivateKeyEndPattern.MatchString(line) && s.privateKeyLines < constants.PrivateKeyMaxLines {
+		return nil
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Close the PEM block, only its type is kept as preview
+	//
+	block := s.privateKeyBlock.String()
+
+	secret := &metadataproto.Secret{
+		Rule:    "private-key",
+		Preview: []byte(s.privateKeyType),
+		Hash:    utils.KeyedHash(s.hmacKey, []byte(block)),
+		Entropy: utils.ShannonEntropy(block),
+	}
+
+	s.privateKeyType = ""
+	s.privateKeyBlock.Reset()
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return secret
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *SecretScanner) ScanLine(line string) []*metadataproto.Secret {
+	var secrets []*metadataproto.Secret
+
+	if secret := s.scanPrivateKey(line); secret != nil {
+		secrets = append(secrets, secret)
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Provider rules, blanked out once matched so the generic rules do not report them twice
+	//
+	masked := []byte(line)
+
+	for _, rule := range constants.SecretRules {
+		for _, loc := range rule.Pattern.FindAllSubmatchIndex(masked, -1) {
+			start, end := loc[2*rule.Group], loc[2*rule.Group+1]
+			value := string(masked[start:end])
+
+			entropy := utils.ShannonEntropy(value)
+			if entropy < rule.MinEntropy {
+				continue
+			}
+
+			secret := &metadataproto.Secret{
+				Rule:    rule.Id,
+				Preview: redactSecret(value),
+				Hash:    utils.KeyedHash(s.hmacKey, []byte(value)),
+				Entropy: entropy,
+			}
+
+			if rule.Id == "jwt" {
+				secret.JwtIssuer, secret.JwtSubject = decodeJwtClaims(value)
+			}
+
+			secrets = append(secrets, secret)
+
+			for i := start; i < end; i++ {
+				masked[i] = ' '
+			}
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return secrets
+}
@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
+	"sort"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LineStats counts lines as the part is read, whichever way it is parsed afterward.
+type LineStats struct {
+	reader io.Reader
+	stats  metadataproto.PartStats
+
+	length   uint64 // of the current line, without its newline
+	blank    bool   // current line is only whitespace so far
+	controls uint64 // control bytes in the current line
+	hasNul   bool
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewLineStats(reader io.Reader) *LineStats {
+	return &LineStats{reader: reader, blank: true}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *LineStats) Read(p []byte) (int, error) {
+	n, err := s.reader.Read(p)
+	s.stats.Bytes += uint64(n)
+
+	for _, b := range p[:n] {
+		switch {
+		case b == '\n':
+			s.endLine()
+			continue
+		case b == 0:
+			s.hasNul = true
+			s.controls++
+		case b == ' ' || b == '\t' || b == '\r':
+		case b < 0x20 || b == 0x7f:
+			s.controls++
+			s.blank = false
+		default:
+			s.blank = false
+		}
+
+		s.length++
+	}
+
+	return n, err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *LineStats) endLine() {
+	s.stats.Lines++
+
+	if s.blank {
+		s.stats.EmptyLines++
+	} else if s.hasNul || float64(s.controls) > constants.BinaryLineControlRatio*float64(s.length) {
+		s.stats.BinaryLines++
+	}
+
+	if s.length > s.stats.LongestLine {
+		s.stats.LongestLine = s.length
+	}
+
+	s.length, s.blank, s.controls, s.hasNul = 0, true, 0, false
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Stats accounts for a last line without trailing newline and returns the counters.
+func (s *LineStats) Stats() *metadataproto.PartStats {
+	if s.length > 0 {
+		s.endLine()
+	}
+
+	return &metadataproto.PartStats{
+		Lines:       s.stats.Lines,
+		Bytes:       s.stats.Bytes,
+		EmptyLines:  s.stats.EmptyLines,
+		BinaryLines: s.stats.BinaryLines,
+		LongestLine: s.stats.LongestLine,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Fragments) matches() []*metadataproto.NamedCount {
+	var hashes uint64
+	for _, hashCount := range f.hashCounts {
+		hashes += hashCount.Count
+	}
+
+	return []*metadataproto.NamedCount{
+		{Name: "accounts", Count: uint64(len(f.accounts))},
+		{Name: "autofill", Count: uint64(len(f.autofill))},
+		{Name: "cards", Count: uint64(len(f.cards))},
+		{Name: "cookies", Count: uint64(len(f.cookies))},
+		{Name: "credentials", Count: uint64(len(f.credentials))},
+		{Name: "domains", Count: uint64(len(f.domains))},
+		{Name: "emails", Count: uint64(len(f.emails))},
+		{Name: "hashes", Count: hashes},
+		{Name: "ibans", Count: uint64(len(f.ibans))},
+		{Name: "ips", Count: uint64(len(f.ips))},
+		{Name: "secrets", Count: uint64(len(f.secrets))},
+		{Name: "sourced_entities", Count: uint64(len(f.sourced))},
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SummaryBuilder folds per-part stats into the directory summary.
+type SummaryBuilder struct {
+	summary    metadataproto.DirectorySummary
+	totals     metadataproto.PartStats
+	matches    map[string]uint64
+	filtered   map[string]uint64
+	delimiters map[string]uint64
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewSummaryBuilder() *SummaryBuilder {
+	return &SummaryBuilder{
+		matches:    make(map[string]uint64),
+		filtered:   make(map[string]uint64),
+		delimiters: make(map[string]uint64),
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (b *SummaryBuilder) Add(stats *metadataproto.PartStats) {
+	b.summary.Parts++
+
+	if stats == nil {
+		return
+	}
+
+	b.totals.Lines += stats.Lines
+	b.totals.Bytes += stats.Bytes
+	b.totals.EmptyLines += stats.EmptyLines
+	b.totals.BinaryLines += stats.BinaryLines
+	b.totals.LongestLine = max(b.totals.LongestLine, stats.LongestLine)
+	b.totals.Records += stats.Records
+	b.totals.InvalidDocuments += stats.InvalidDocuments
+
+	if stats.EarliestDate != 0 && (b.totals.EarliestDate == 0 || stats.EarliestDate < b.totals.EarliestDate) {
+		b.totals.EarliestDate = stats.EarliestDate
+	}
+	b.totals.LatestDate = max(b.totals.LatestDate, stats.LatestDate)
+
+	for _, match := range stats.Matches {
+		b.matches[match.Name] += match.Count
+	}
+	for _, filtered := range stats.Filtered {
+		b.filtered[filtered.Name] += filtered.Count
+	}
+
+	if stats.Delimiter != "" {
+		b.delimiters[stats.Delimiter]++
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (b *SummaryBuilder) AddFailed() {
+	b.summary.FailedParts++
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func sortedNamedCounts(counts map[string]uint64) []*metadataproto.NamedCount {
+	named := make([]*metadataproto.NamedCount, 0, len(counts))
+
+	for name, count := range counts {
+		named = append(named, &metadataproto.NamedCount{Name: name, Count: count})
+	}
+
+	sort.Slice(named, func(i, j int) bool {
+		return named[i].Name < named[j].Name
+	})
+
+	return named
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (b *SummaryBuilder) Summary() *metadataproto.DirectorySummary {
+	totals := &metadataproto.PartStats{
+		Lines:            b.totals.Lines,
+		Bytes:            b.totals.Bytes,
+		EmptyLines:       b.totals.EmptyLines,
+		BinaryLines:      b.totals.BinaryLines,
+		LongestLine:      b.totals.LongestLine,
+		Matches:          sortedNamedCounts(b.matches),
+		Filtered:         sortedNamedCounts(b.filtered),
+		Records:          b.totals.Records,
+		InvalidDocuments: b.totals.InvalidDocuments,
+		EarliestDate:     b.totals.EarliestDate,
+		LatestDate:       b.totals.LatestDate,
+	}
+
+	return &metadataproto.DirectorySummary{
+		Parts:       b.summary.Parts,
+		FailedParts: b.summary.FailedParts,
+		Totals:      totals,
+		Delimiters:  sortedNamedCounts(b.delimiters),
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type Handle struct {
+	Source   string // platform of the profile URL, or "mention"
+	Username string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanHandles finds the usernames of profile URLs and "@name" mentions, emails and "@example.com" left aside.
+func ScanHandles(text string) []Handle {
+	var handles []Handle
+
+	for _, rule := range constants.ProfileUrlRules {
+		if !strings.Contains(text, rule.Host) {
+			continue
+		}
+
+		for _, match := range rule.Pattern.FindAllStringSubmatch(text, -1) {
+			username := strings.TrimRight(match[1], ".")
+			if username != "" && !slices.Contains(rule.Reserved, strings.ToLower(username)) {
+				handles = append(handles, Handle{Source: rule.Platform, Username: username})
+			}
+		}
+	}
+
+	for at := strings.IndexByte(text, '@'); at >= 0; {
+		if username, ok := mentionAt(text, at); ok {
+			handles = append(handles, Handle{Source: constants.HandleMentionSource, Username: username})
+		}
+
+		next := strings.IndexByte(text[at+1:], '@')
+		if next < 0 {
+			break
+		}
+		at += next + 1
+	}
+
+	return handles
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// mentionAt reads the "@name" mention at the '@', which must start the text or follow a space or an opening bracket,
+// and must not be followed by a domain.
+func mentionAt(text string, at int) (string, bool) {
+	if at > 0 && !strings.ContainsRune(" \t([,;\"'", rune(text[at-1])) {
+		return "", false
+	}
+
+	end := at + 1
+	for end < len(text) && is(text, end, classWord) {
+		end++
+	}
+
+	length := end - at - 1
+	if length < constants.HandleMentionMinLength || length > constants.HandleMentionMaxLength {
+		return "", false
+	}
+
+	// "@example.com", "@john@example.com"
+	if end < len(text) && (text[end] == '@' || (text[end] == '.' && is(text, end+1, classWord))) {
+		return "", false
+	}
+
+	return text[at+1 : end], true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanHandles sources the usernames of text from their platform.
+func (f *Fragments) scanHandles(text string) {
+	for _, handle := range ScanHandles(text) {
+		f.sourced = append(f.sourced, &metadataproto.SourcedEntity{
+			Type:   constants.EntityTypeUsername,
+			Value:  []byte(handle.Username),
+			Origin: metadataproto.EntityOrigin_ENTITY_ORIGIN_HANDLE,
+			Source: handle.Source,
+		})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// scanUsernameLine sources "login: john" like lines from their prefix.
+func (f *Fragments) scanUsernameLine(line string) {
+	match := constants.UsernameLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+
+	f.sourced = append(f.sourced, &metadataproto.SourcedEntity{
+		Type:   constants.EntityTypeUsername,
+		Value:  []byte(match[2]),
+		Origin: metadataproto.EntityOrigin_ENTITY_ORIGIN_HANDLE,
+		Source: NormalizeColumnName(match[1]),
+	})
+}
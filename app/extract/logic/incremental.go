@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PreviousPart locates an item of an existing _metadata.pb, so it can be copied as is when its part did not change.
+type PreviousPart struct {
+	Offset           int64
+	Size             int
+	PartHash         uint64
+	ExtractorVersion uint32
+	Stats            *metadataproto.PartStats
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LoadPreviousParts also returns the privacy and the options fingerprint the items were extracted under, nil and ""
+// for streams predating them.
+func LoadPreviousParts(metadataFilePath string) (map[string]PreviousPart, *metadataproto.Privacy, string, error) {
+	reader, err := utils.OpenDelimitedReader(metadataFilePath)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer func(reader *utils.DelimitedReader) {
+		if err := reader.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", metadataFilePath, err)
+		}
+	}(reader)
+
+	var (
+		previous           = make(map[string]PreviousPart)
+		privacy            *metadataproto.Privacy
+		optionsFingerprint string
+	)
+
+	for {
+		field, data, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to read metadata %s: %v", metadataFilePath, err)
+			logger.Logger.Error().Msg(msg)
+
+			return nil, nil, "", fmt.Errorf(msg)
+		}
+
+		if field == constants.MetadataListPrivacyField {
+			privacy = &metadataproto.Privacy{}
+			if err := proto.Unmarshal(data, privacy); err != nil {
+				var msg = fmt.Sprintf("Failed to unmarshal privacy in %s: %v", metadataFilePath, err)
+				logger.Logger.Error().Msg(msg)
+
+				return nil, nil, "", fmt.Errorf(msg)
+			}
+			continue
+		}
+
+		if field == constants.MetadataListOptionsField {
+			optionsFingerprint = string(data)
+			continue
+		}
+
+		if field != constants.MetadataListItemsField {
+			continue
+		}
+
+		item := &metadataproto.Metadata{}
+		if err := proto.Unmarshal(data, item); err != nil {
+			var msg = fmt.Sprintf("Failed to unmarshal metadata item in %s: %v", metadataFilePath, err)
+			logger.Logger.Error().Msg(msg)
+
+			return nil, nil, "", fmt.Errorf(msg)
+		}
+
+		previous[item.Id] = PreviousPart{
+			Offset:           reader.Offset() - int64(len(data)),
+			Size:             len(data),
+			PartHash:         item.PartHash,
+			ExtractorVersion: item.ExtractorVersion,
+			Stats:            item.Stats,
+		}
+	}
+
+	return previous, privacy, optionsFingerprint, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SamePrivacy reports whether items extracted under both privacy settings can be stored together.
+func SamePrivacy(a *metadataproto.Privacy, b *metadataproto.Privacy) bool {
+	return a.GetMode() == b.GetMode() && a.GetKeyId() == b.GetKeyId()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Unchanged reports whether the item was extracted from the same content by the current extractors.
+func (p PreviousPart) Unchanged(metadataInfo *infoproto.MetadataInfo) bool {
+	return p.PartHash == metadataInfo.Simhash && p.ExtractorVersion == constants.ExtractorVersion
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (p PreviousPart) Read(file *os.File) ([]byte, error) {
+	data := make([]byte, p.Size)
+
+	if _, err := file.ReadAt(data, p.Offset); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,28 @@
+package structs
+
+import (
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/mmdb"
+	"github.com/Rom1-J/preprocessor/pkg/noise"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"net/netip"
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ExtractOptsStruct struct {
+	HmacKey          []byte
+	PasswordMode     string
+	PrivacyMode      string
+	RecordMode       string
+	RecordStart      *regexp.Regexp
+	ColumnDictionary []constants.ColumnRule
+	IpDatabases      []*mmdb.Reader
+	DroppedIpClasses []metadataproto.IpClass
+	DropVersionIps   bool
+	IpDenylist       []netip.Prefix
+	NoiseFilters     map[infoproto.Bucket]*noise.Filter
+}
@@ -34,4 +34,12 @@ var Flags = []ucli.Flag{
 		Value:    int64(runtime.NumCPU()),
 		Required: false,
 	},
+	&ucli.StringSliceFlag{
+		Name:  "freemail-list",
+		Usage: "File of extra freemail provider domains (one per line, # comments) classifying emails, on top of the embedded list",
+	},
+	&ucli.StringSliceFlag{
+		Name:  "disposable-list",
+		Usage: "File of extra disposable provider domains (one per line, # comments) classifying emails, on top of the embedded list",
+	},
 }
@@ -3,15 +3,16 @@ package logic
 import (
 	"fmt"
 	"github.com/Rom1-J/preprocessor/app/optimize/logic/generator"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
 	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
 	"github.com/jedib0t/go-pretty/v6/progress"
 	"google.golang.org/protobuf/proto"
 	"io"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sync"
 )
 
@@ -21,98 +22,105 @@ import (
 func OptimizeMetadata(
 	globalProgress prog.ProgressOptsStruct,
 	inputDirectory string,
+	emailClassifier *generator.EmailClassifier,
 ) error {
 	logger.Logger.Trace().Msgf("OptimizeMetadata starting on: %s", inputDirectory)
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Initialize tracker
-	//
-	tracker := progress.Tracker{
-		Message: "Processing directory " + filepath.Base(inputDirectory),
-		Total:   int64(0),
-	}
-	globalProgress.Pw.AppendTracker(&tracker)
-	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
-
-	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-	//
-	// Open metadata protobuf
+	// Open metadata protobuf, streamed item by item, see utils.DelimitedWriter
 	//
 	metadataFilePath := filepath.Join(inputDirectory, "_metadata.pb")
+	optimizedMetadataFilePath := filepath.Join(inputDirectory, "_metadata.opti.pb")
 
-	metadataFile, err := os.Open(metadataFilePath)
+	metadataInfo, err := os.Stat(metadataFilePath)
 	if err != nil {
 		logger.Logger.Error().Msgf("Failed to open metadata file %s: %v", metadataFilePath, err)
 		return err
 	}
 
-	metadataData, err := io.ReadAll(metadataFile)
+	reader, err := utils.OpenDelimitedReader(metadataFilePath)
 	if err != nil {
-		logger.Logger.Error().Msgf("Failed to read metadata file %s: %v", metadataFilePath, err)
 		return err
 	}
-	if err := metadataFile.Close(); err != nil {
-		logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", metadataFilePath, err)
-	}
+	defer func(reader *utils.DelimitedReader) {
+		if err := reader.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", metadataFilePath, err)
+		}
+	}(reader)
 
-	metadata := &metadataproto.MetadataList{}
-	err = proto.Unmarshal(metadataData, metadata)
+	writer, err := utils.CreateDelimitedWriter(optimizedMetadataFilePath)
 	if err != nil {
-		logger.Logger.Error().Msgf("Failed to unmarshal protobuf data for %s: %v", metadataFilePath, err)
 		return err
 	}
-	metadataData = nil
-
-	tracker.UpdateMessage(fmt.Sprintf("Processing directory %s (%d items)", filepath.Base(inputDirectory), len(metadata.Items)))
-	tracker.UpdateTotal(int64(len(metadata.Items)))
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Optimize metadata
+	// Initialize tracker
 	//
-	for _, item := range metadata.Items {
-		var wg sync.WaitGroup
-		wg.Add(3)
-
-		go func(m *metadataproto.Metadata) {
-			defer wg.Done()
-			m.Emails = generator.DeduplicateItems(m.Emails)
-		}(item)
-
-		go func(m *metadataproto.Metadata) {
-			defer wg.Done()
-			m.Ips = generator.DeduplicateItems(m.Ips)
-		}(item)
-
-		go func(m *metadataproto.Metadata) {
-			defer wg.Done()
-			m.Domains = generator.DeduplicateItems(m.Domains)
-		}(item)
-
-		wg.Wait()
-		logger.Logger.Trace().Msgf("Metadata %s dedupped", item.Id)
-		tracker.Increment(1)
+	tracker := progress.Tracker{
+		Message: "Processing directory " + filepath.Base(inputDirectory),
+		Total:   metadataInfo.Size(),
+		Units:   progress.UnitsBytes,
 	}
+	globalProgress.Pw.AppendTracker(&tracker)
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Saving optimized metadata
+	// Optimize metadata, the other fields being copied as is
 	//
-	optimizedMetadataFilePath := filepath.Join(inputDirectory, "_metadata.opti.pb")
-
-	optimizedData, err := proto.Marshal(metadata)
-	if err != nil {
-		logger.Logger.Error().Msgf("Error encoding optimized metadata %s: %v", optimizedMetadataFilePath, err)
-		return err
-	}
-
-	err = os.WriteFile(optimizedMetadataFilePath, optimizedData, 0644)
-	if err != nil {
-		logger.Logger.Error().Msgf("Error creating optimized metadata %s: %v", optimizedMetadataFilePath, err)
-		return err
+	items := 0
+
+	for {
+		field, data, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writer.Abort()
+			tracker.MarkAsErrored()
+
+			var msg = fmt.Sprintf("Failed to read metadata %s: %v", metadataFilePath, err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		switch field {
+		case constants.MetadataListItemsField:
+			item := &metadataproto.Metadata{}
+			if err = proto.Unmarshal(data, item); err == nil {
+				OptimizeItem(item, emailClassifier)
+				err = writer.Write(field, item)
+				items++
+			}
+		case constants.MetadataListPrivacyField:
+			privacy := &metadataproto.Privacy{}
+			if err = proto.Unmarshal(data, privacy); err == nil {
+				err = writer.WriteRaw(field, data)
+			}
+
+			if privacy.GetMode() == metadataproto.PrivacyMode_PRIVACY_MODE_HMAC && emailClassifier != nil {
+				logger.Logger.Warn().Msgf("Emails of %s are keyed hashes, leaving them unclassified", metadataFilePath)
+				emailClassifier = nil
+			}
+		default:
+			err = writer.WriteRaw(field, data)
+		}
+
+		if err != nil {
+			writer.Abort()
+			tracker.MarkAsErrored()
+
+			var msg = fmt.Sprintf("Error optimizing metadata %s: %v", optimizedMetadataFilePath, err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+
+		tracker.SetValue(reader.Offset())
 	}
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
@@ -120,11 +128,87 @@ func OptimizeMetadata(
 	//
 	// Closing output descriptor
 	//
-	metadata = nil
-	runtime.GC()
+	if err := writer.Close(); err != nil {
+		tracker.MarkAsErrored()
+		logger.Logger.Error().Msgf("Error creating optimized metadata %s: %v", optimizedMetadataFilePath, err)
+		return err
+	}
 
+	tracker.UpdateMessage(fmt.Sprintf("Processing directory %s (%d items)", filepath.Base(inputDirectory), items))
 	tracker.MarkAsDone()
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 	return nil
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OptimizeItem deduplicates the entities of the item, and classifies its emails unless emailClassifier is nil.
+func OptimizeItem(item *metadataproto.Metadata, emailClassifier *generator.EmailClassifier) {
+	var wg sync.WaitGroup
+	wg.Add(11)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Emails = generator.DeduplicateItems(m.Emails)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Ips = generator.DeduplicateItems(m.Ips)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Domains = generator.DeduplicateItems(m.Domains)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Cards = generator.DeduplicateMessages(m.Cards, generator.PaymentCardKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Ibans = generator.DeduplicateMessages(m.Ibans, generator.IbanKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Secrets = generator.DeduplicateMessages(m.Secrets, generator.SecretKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Credentials = generator.DeduplicateMessages(m.Credentials, generator.CredentialKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.SourcedEntities = generator.DeduplicateMessages(m.SourcedEntities, generator.SourcedEntityKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.Accounts = generator.DeduplicateMessages(m.Accounts, generator.AccountKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		m.IpInfos = generator.DeduplicateMessages(m.IpInfos, generator.IpInfoKey)
+	}(item)
+
+	go func(m *metadataproto.Metadata) {
+		defer wg.Done()
+		if m.BrowserArtifacts != nil {
+			m.BrowserArtifacts.Cookies = generator.DeduplicateMessages(m.BrowserArtifacts.Cookies, generator.CookieKey)
+		}
+	}(item)
+
+	wg.Wait()
+	logger.Logger.Trace().Msgf("Metadata %s dedupped", item.Id)
+
+	if emailClassifier != nil {
+		item.EmailInfos = emailClassifier.EmailInfos(item)
+	}
+}
@@ -20,3 +20,19 @@ func DeduplicateItems(input [][]byte) [][]byte {
 	}
 	return result
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func DeduplicateMessages[T any](input []T, key func(T) string) []T {
+	seen := make(map[string]struct{})
+	var result []T
+
+	for _, m := range input {
+		k := key(m)
+		if _, exists := seen[k]; !exists {
+			result = append(result, m)
+			seen[k] = struct{}{}
+		}
+	}
+	return result
+}
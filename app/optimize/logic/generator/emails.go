@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EmailClassifier tells freemail and disposable addresses apart from corporate ones, from the embedded provider lists
+// and the --freemail-list and --disposable-list files.
+type EmailClassifier struct {
+	freemail   map[string]bool
+	disposable map[string]bool
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func NewEmailClassifier(freemailPaths []string, disposablePaths []string) (*EmailClassifier, error) {
+	classifier := &EmailClassifier{freemail: make(map[string]bool), disposable: make(map[string]bool)}
+
+	readDomainList(strings.NewReader(constants.FreemailDomains), classifier.freemail)
+	readDomainList(strings.NewReader(constants.DisposableDomains), classifier.disposable)
+
+	for _, list := range []struct {
+		paths   []string
+		domains map[string]bool
+	}{
+		{freemailPaths, classifier.freemail},
+		{disposablePaths, classifier.disposable},
+	} {
+		for _, path := range list.paths {
+			file, err := os.Open(path)
+			if err != nil {
+				var msg = fmt.Sprintf("Failed to open provider list %s: %v", path, err)
+				logger.Logger.Error().Msg(msg)
+
+				return nil, fmt.Errorf(msg)
+			}
+
+			readDomainList(file, list.domains)
+
+			if err := file.Close(); err != nil {
+				logger.Logger.Error().Msgf("Failed to close provider list %s: %v", path, err)
+			}
+		}
+	}
+
+	return classifier, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func readDomainList(reader io.Reader, domains map[string]bool) {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(line)), "."); line != "" {
+			domains[line] = true
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Classify sorts the address by the provider of its domain or of a parent domain, disposable ones going first.
+func (c *EmailClassifier) Classify(email string) *metadataproto.EmailInfo {
+	email = strings.ToLower(email)
+	at := strings.LastIndexByte(email, '@')
+
+	local, _, _ := strings.Cut(email[:max(at, 0)], "+")
+	info := &metadataproto.EmailInfo{
+		Email: []byte(email),
+		Role:  slices.Contains(constants.EmailRoleLocalParts, local),
+	}
+
+	for domain := email[at+1:]; domain != ""; {
+		if c.disposable[domain] {
+			info.Category = metadataproto.EmailCategory_EMAIL_CATEGORY_DISPOSABLE
+			break
+		}
+		if c.freemail[domain] {
+			info.Category = metadataproto.EmailCategory_EMAIL_CATEGORY_FREEMAIL
+			break
+		}
+
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+
+	return info
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EmailInfos classifies the distinct emails of the item, sourced ones included.
+func (c *EmailClassifier) EmailInfos(metadata *metadataproto.Metadata) []*metadataproto.EmailInfo {
+	var (
+		infos []*metadataproto.EmailInfo
+		seen  = make(map[string]bool)
+	)
+
+	classify := func(email string) {
+		if key := strings.ToLower(email); !seen[key] {
+			seen[key] = true
+			infos = append(infos, c.Classify(email))
+		}
+	}
+
+	for _, email := range metadata.Emails {
+		classify(string(email))
+	}
+	for _, entity := range metadata.SourcedEntities {
+		if entity.Type == constants.EntityTypeEmail {
+			classify(string(entity.Value))
+		}
+	}
+
+	return infos
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func PaymentCardKey(card *metadataproto.PaymentCard) string {
+	if len(card.Hash) > 0 {
+		return string(card.Hash)
+	}
+	return string(card.Masked)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IbanKey(iban *metadataproto.Iban) string {
+	if len(iban.Hash) > 0 {
+		return string(iban.Hash)
+	}
+	return string(iban.Masked)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func SecretKey(secret *metadataproto.Secret) string {
+	if len(secret.Hash) > 0 {
+		return string(secret.Hash)
+	}
+	return secret.Rule + "/" + string(secret.Preview)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CredentialKey, AccountKey and SourcedEntityKey keep the record number, the same value seen in two records is two
+// findings.
+func CredentialKey(credential *metadataproto.Credential) string {
+	return strings.ToLower(string(credential.Email)) + "/" + credential.PasswordKind.String() + "/" +
+		string(credential.PasswordHash) + "/" + strconv.FormatUint(credential.Record, 10)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func AccountKey(account *metadataproto.Account) string {
+	return string(account.Username) + "/" + strings.ToLower(string(account.Email)) + "/" + account.Source + "/" +
+		strconv.FormatUint(account.Record, 10)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func SourcedEntityKey(entity *metadataproto.SourcedEntity) string {
+	return entity.Type + "/" + entity.Origin.String() + "/" + entity.Source + "/" + string(entity.Value) + "/" +
+		strconv.FormatUint(entity.Record, 10)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IpInfoKey keeps one annotation per IP, the same databases giving the same one.
+func IpInfoKey(info *metadataproto.IpInfo) string {
+	return string(info.Ip)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CookieKey tells cookies apart by value hash as well, the same session cookie exported twice being one.
+func CookieKey(cookie *metadataproto.Cookie) string {
+	return strings.ToLower(string(cookie.Domain)) + "/" + cookie.Name + "/" + string(cookie.ValueHash)
+}
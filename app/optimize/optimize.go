@@ -3,6 +3,7 @@ package optimize
 import (
 	"context"
 	"github.com/Rom1-J/preprocessor/app/optimize/logic"
+	"github.com/Rom1-J/preprocessor/app/optimize/logic/generator"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
 	ucli "github.com/urfave/cli/v3"
@@ -60,6 +61,19 @@ func Action(ctx context.Context, command *ucli.Command) error {
 	logger.Logger.Info().Msgf("Optimizing %d files", len(inputList))
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Loading email provider lists
+	//
+	emailClassifier, err := generator.NewEmailClassifier(
+		command.StringSlice("freemail-list"),
+		command.StringSlice("disposable-list"),
+	)
+	if err != nil {
+		return err
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
 	// Initialize progress bar
@@ -96,6 +110,7 @@ func Action(ctx context.Context, command *ucli.Command) error {
 			if err := logic.OptimizeMetadata(
 				globalProgress,
 				ipd,
+				emailClassifier,
 			); err != nil {
 				logger.Logger.Error().Msgf("Cannot optimize file '%s': %s", ipd, err)
 			}
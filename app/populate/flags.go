@@ -2,6 +2,7 @@ package populate
 
 import (
 	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
 	ucli "github.com/urfave/cli/v3"
 	"runtime"
 	"strings"
@@ -56,4 +57,39 @@ var Flags = []ucli.Flag{
 		Value:    int64(runtime.NumCPU()),
 		Required: false,
 	},
+	&ucli.StringFlag{
+		Name:  "browser-artifacts",
+		Usage: "Cookies and autofill indexed: none, counts (cookie domains and counts) or full (names, cookie value hashes and autofill entity types)",
+		Value: constants.BrowserArtifactsCounts,
+		Validator: func(s string) error {
+			switch strings.ToLower(s) {
+			case
+				constants.BrowserArtifactsNone,
+				constants.BrowserArtifactsCounts,
+				constants.BrowserArtifactsFull:
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.BrowserArtifactsModes, ", "), s)
+		},
+	},
+	&ucli.StringFlag{
+		Name:  "privacy-mode",
+		Usage: "Privacy mode the collection holds, metadata extracted with another mode is refused: plaintext or hmac",
+		Value: constants.PrivacyModePlaintext,
+		Validator: func(s string) error {
+			switch strings.ToLower(s) {
+			case
+				constants.PrivacyModePlaintext,
+				constants.PrivacyModeHmac:
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.PrivacyModes, " or "), s)
+		},
+	},
+	&ucli.StringFlag{
+		Name:    "hmac-key",
+		Sources: ucli.EnvVars("HMAC_KEY"),
+		Usage:   "Key the collection is hashed with, required by --privacy-mode hmac to refuse metadata hashed with another one",
+		Value:   "",
+	},
 }
@@ -6,25 +6,316 @@ import (
 	"fmt"
 	"github.com/Rom1-J/preprocessor/app/populate/logic/generator"
 	"github.com/Rom1-J/preprocessor/app/populate/structs"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
 	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
 	"github.com/jedib0t/go-pretty/v6/progress"
 	"google.golang.org/protobuf/proto"
 	"io"
-	"log"
 	"net/http"
-	"os"
 	"path/filepath"
+	"slices"
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// MatchesPrivacy reports whether metadata extracted under the given privacy can go in the collection. Only hashed
+// collections are bound to a key, payment card and IBAN hashes aside plaintext ones can be looked up without it.
+func MatchesPrivacy(metadata *metadataproto.Privacy, collection *metadataproto.Privacy) bool {
+	if metadata.GetMode() != collection.GetMode() {
+		return false
+	}
+
+	return collection.GetMode() != metadataproto.PrivacyMode_PRIVACY_MODE_HMAC || metadata.GetKeyId() == collection.GetKeyId()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AddBrowserArtifacts indexes the cookie domains and the cookie and autofill counts, and when full the cookie names
+// and value hashes and the autofill names, each with the types of the entities sourced from it. Cookie values are
+// never part of the metadata. Autofill values, which may hold cards or secrets, are never indexed, nor the entities
+// sourced from them, NewSolrDocument leaving those out of the autofill_* dynamic fields whatever the mode.
+func AddBrowserArtifacts(
+	doc *structs.SolrDocument,
+	artifacts *metadataproto.BrowserArtifacts,
+	entities []*metadataproto.SourcedEntity,
+	full bool,
+) {
+	doc.CookieCount = uint64(len(artifacts.GetCookies()))
+	doc.AutofillCount = uint64(len(artifacts.GetAutofill()))
+
+	for _, cookie := range artifacts.GetCookies() {
+		if !slices.Contains(doc.CookieDomains, string(cookie.Domain)) {
+			doc.CookieDomains = append(doc.CookieDomains, string(cookie.Domain))
+		}
+
+		if full {
+			doc.CookieNames = append(doc.CookieNames, cookie.Name)
+			doc.CookieValueHashes = append(doc.CookieValueHashes, string(cookie.ValueHash))
+		}
+	}
+
+	if !full {
+		return
+	}
+
+	for _, field := range artifacts.GetAutofill() {
+		doc.AutofillNames = append(doc.AutofillNames, field.Name)
+
+		name := generator.DynamicFieldName("autofill_field", field.Name, "ss")
+		types, _ := doc.Dynamic[name].([]string)
+
+		for _, entity := range entities {
+			if entity.Origin == metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL && entity.Record == field.Record &&
+				!slices.Contains(types, entity.Type) {
+				types = append(types, entity.Type)
+			}
+		}
+
+		if len(types) > 0 {
+			if doc.Dynamic == nil {
+				doc.Dynamic = make(map[string]interface{})
+			}
+			doc.Dynamic[name] = types
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewSolrDocument converts an item, the dataset dates coming from the summary of its directory.
+func NewSolrDocument(
+	item *metadataproto.Metadata,
+	summary *metadataproto.DirectorySummary,
+	solrOpts structs.SolrOptsStruct,
+) structs.SolrDocument {
+	doc := structs.SolrDocument{
+		ID:      item.Id,
+		Emails:  generator.ConvertBytesToStrings(item.Emails),
+		IPs:     generator.ConvertBytesToStrings(item.Ips),
+		Domains: generator.ConvertBytesToStrings(item.Domains),
+
+		EarliestDate:        generator.SolrDate(item.Stats.GetEarliestDate()),
+		LatestDate:          generator.SolrDate(item.Stats.GetLatestDate()),
+		DatasetEarliestDate: generator.SolrDate(summary.GetTotals().GetEarliestDate()),
+		DatasetLatestDate:   generator.SolrDate(summary.GetTotals().GetLatestDate()),
+	}
+
+	for _, card := range item.Cards {
+		doc.Cards = append(doc.Cards, string(card.Masked))
+		if len(card.Hash) > 0 {
+			doc.CardHashes = append(doc.CardHashes, string(card.Hash))
+		}
+		doc.CardNetworks = append(doc.CardNetworks, card.Network)
+	}
+
+	for _, iban := range item.Ibans {
+		doc.Ibans = append(doc.Ibans, string(iban.Masked))
+		if len(iban.Hash) > 0 {
+			doc.IbanHashes = append(doc.IbanHashes, string(iban.Hash))
+		}
+		doc.IbanCountries = append(doc.IbanCountries, iban.Country)
+	}
+
+	for _, hash := range item.Hashes {
+		field := generator.DynamicFieldName("hashes", hash.Algorithm, "l")
+		if doc.Dynamic == nil {
+			doc.Dynamic = make(map[string]interface{})
+		}
+
+		if count, ok := doc.Dynamic[field].(uint64); ok {
+			doc.Dynamic[field] = count + hash.Count
+		} else {
+			doc.HashAlgorithms = append(doc.HashAlgorithms, hash.Algorithm)
+			doc.Dynamic[field] = hash.Count
+		}
+	}
+
+	for _, secret := range item.Secrets {
+		doc.SecretRules = append(doc.SecretRules, secret.Rule)
+		doc.SecretPreviews = append(doc.SecretPreviews, string(secret.Preview))
+		if len(secret.Hash) > 0 {
+			doc.SecretHashes = append(doc.SecretHashes, string(secret.Hash))
+		}
+		if secret.JwtIssuer != "" {
+			doc.JwtIssuers = append(doc.JwtIssuers, secret.JwtIssuer)
+		}
+		if secret.JwtSubject != "" {
+			doc.JwtSubjects = append(doc.JwtSubjects, secret.JwtSubject)
+		}
+	}
+
+	for _, credential := range item.Credentials {
+		doc.CredentialEmails = append(doc.CredentialEmails, string(credential.Email))
+		doc.PasswordKinds = append(doc.PasswordKinds, generator.EnumName(credential.PasswordKind.String(), "PASSWORD_KIND_"))
+		if len(credential.PasswordHash) > 0 {
+			doc.PasswordHashes = append(doc.PasswordHashes, string(credential.PasswordHash))
+		}
+	}
+
+	for _, info := range item.IpInfos {
+		class := generator.EnumName(info.Class.String(), "IP_CLASS_")
+		if !slices.Contains(doc.IpClasses, class) {
+			doc.IpClasses = append(doc.IpClasses, class)
+		}
+		if info.Country != "" && !slices.Contains(doc.IpCountries, info.Country) {
+			doc.IpCountries = append(doc.IpCountries, info.Country)
+		}
+		if info.Asn != 0 && !slices.Contains(doc.IpAsns, info.Asn) {
+			doc.IpAsns = append(doc.IpAsns, info.Asn)
+		}
+		if info.Organization != "" && !slices.Contains(doc.IpOrganizations, info.Organization) {
+			doc.IpOrganizations = append(doc.IpOrganizations, info.Organization)
+		}
+	}
+
+	for _, info := range item.EmailInfos {
+		category := generator.EnumName(info.Category.String(), "EMAIL_CATEGORY_")
+		if !slices.Contains(doc.EmailCategories, category) {
+			doc.EmailCategories = append(doc.EmailCategories, category)
+		}
+		if info.Category == metadataproto.EmailCategory_EMAIL_CATEGORY_CORPORATE {
+			domain := string(info.Email[bytes.LastIndexByte(info.Email, '@')+1:])
+			if !slices.Contains(doc.CorporateEmailDomains, domain) {
+				doc.CorporateEmailDomains = append(doc.CorporateEmailDomains, domain)
+			}
+		}
+		if info.Role {
+			doc.RoleEmails = append(doc.RoleEmails, string(info.Email))
+		}
+	}
+
+	for _, account := range item.Accounts {
+		doc.UsernameEmails = append(doc.UsernameEmails, string(account.Username)+" "+string(account.Email))
+	}
+
+	for _, entity := range item.SourcedEntities {
+		// typed in browser forms, see AddBrowserArtifacts
+		if entity.Origin == metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL {
+			continue
+		}
+
+		field := generator.DynamicFieldName(generator.EnumName(entity.Origin.String(), "ENTITY_ORIGIN_"), entity.Type, "ss")
+		if doc.Dynamic == nil {
+			doc.Dynamic = make(map[string]interface{})
+		}
+
+		values, _ := doc.Dynamic[field].([]string)
+		doc.Dynamic[field] = append(values, string(entity.Value))
+
+		if entity.Type == constants.EntityTypeUsername && !slices.Contains(doc.Usernames, string(entity.Value)) {
+			doc.Usernames = append(doc.Usernames, string(entity.Value))
+		}
+
+		if entity.Source != "" && !slices.Contains(doc.SourcedColumns, entity.Source) {
+			doc.SourcedColumns = append(doc.SourcedColumns, entity.Source)
+		}
+	}
+
+	if solrOpts.BrowserArtifacts != constants.BrowserArtifactsNone {
+		AddBrowserArtifacts(
+			&doc,
+			item.BrowserArtifacts,
+			item.SourcedEntities,
+			solrOpts.BrowserArtifacts == constants.BrowserArtifactsFull,
+		)
+	}
+
+	for _, field := range item.Schema {
+		doc.SchemaFields = append(doc.SchemaFields, field.Path)
+	}
+
+	return doc
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReadMetadataHeader reads the summary and the privacy of a _metadata.pb, the summary coming after the items.
+func ReadMetadataHeader(inputMetadataPb string) (*metadataproto.DirectorySummary, *metadataproto.Privacy, error) {
+	reader, err := utils.OpenDelimitedReader(inputMetadataPb)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(reader *utils.DelimitedReader) {
+		if err := reader.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", inputMetadataPb, err)
+		}
+	}(reader)
+	reader.Skip(constants.MetadataListItemsField)
+
+	var (
+		summary = &metadataproto.DirectorySummary{}
+		privacy = &metadataproto.Privacy{}
+	)
+
+	for {
+		field, data, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err == nil {
+			switch field {
+			case constants.MetadataListSummaryField:
+				err = proto.Unmarshal(data, summary)
+			case constants.MetadataListPrivacyField:
+				err = proto.Unmarshal(data, privacy)
+			}
+		}
+
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to read metadata %s: %v", inputMetadataPb, err)
+			logger.Logger.Error().Msg(msg)
+
+			return nil, nil, fmt.Errorf(msg)
+		}
+	}
+
+	return summary, privacy, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func PostDocuments(url string, docs []structs.SolrDocument) error {
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal docs: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("Failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to send request: %v", err)
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			logger.Logger.Warn().Msgf("Failed to close response body: %v", err)
+		}
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to send request: status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func ProcessMetadataPb(
 	globalProgress prog.ProgressOptsStruct,
 	inputMetadataPb string,
 	solrOpts structs.SolrOptsStruct,
+	privacy *metadataproto.Privacy,
 ) error {
 	logger.Logger.Trace().Msgf("ProcessMetadataPb starting on: %s", inputMetadataPb)
 
@@ -48,77 +339,94 @@ func ProcessMetadataPb(
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Open metadata protobuf
+	// Open metadata protobuf, streamed item by item, see utils.DelimitedWriter
 	//
-	metadataData, err := os.ReadFile(inputMetadataPb)
+	summary, metadataPrivacy, err := ReadMetadataHeader(inputMetadataPb)
 	if err != nil {
-		log.Fatalf("Failed to read metadata file %s: %v", inputMetadataPb, err)
+		tracker.MarkAsErrored()
+		return err
 	}
 
-	metadata := &metadataproto.MetadataList{}
-	err = proto.Unmarshal(metadataData, metadata)
+	if !MatchesPrivacy(metadataPrivacy, privacy) {
+		tracker.MarkAsErrored()
+
+		var msg = fmt.Sprintf(
+			"Metadata %s was extracted with privacy mode %s (key %q), collection expects %s (key %q)",
+			inputMetadataPb,
+			metadataPrivacy.GetMode(),
+			metadataPrivacy.GetKeyId(),
+			privacy.GetMode(),
+			privacy.GetKeyId(),
+		)
+		logger.Logger.Error().Msg(msg)
+
+		return fmt.Errorf(msg)
+	}
+
+	reader, err := utils.OpenDelimitedReader(inputMetadataPb)
 	if err != nil {
-		log.Fatalf("Failed to unmarshal protobuf data for %s: %v", inputMetadataPb, err)
+		tracker.MarkAsErrored()
+		return err
 	}
+	defer func(reader *utils.DelimitedReader) {
+		if err := reader.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close metadata file %s: %v", inputMetadataPb, err)
+		}
+	}(reader)
+	reader.Skip(constants.MetadataListSummaryField)
 
-	tracker.UpdateMessage(fmt.Sprintf("Processing directory %s (%d items)", filepath.Base(inputMetadataPb), len(metadata.Items)))
-	tracker.UpdateTotal(int64(len(metadata.Items)))
+	tracker.UpdateMessage(fmt.Sprintf("Processing directory %s (%d items)", filepath.Base(inputMetadataPb), summary.GetParts()))
+	tracker.UpdateTotal(int64(summary.GetParts()))
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Load chunks
+	// Load items by batches of SolrBatchSize
 	//
-	for chunk := range generator.CreateChunks(metadata.Items) {
-		var docs []structs.SolrDocument
-
-		for _, item := range chunk {
-			doc := structs.SolrDocument{
-				ID:      item.Id,
-				Emails:  generator.ConvertBytesToStrings(item.Emails),
-				IPs:     generator.ConvertBytesToStrings(item.Ips),
-				Domains: generator.ConvertBytesToStrings(item.Domains),
-			}
-			docs = append(docs, doc)
-		}
+	docs := make([]structs.SolrDocument, 0, constants.SolrBatchSize)
 
-		data, err := json.Marshal(docs)
-		if err != nil {
+	flush := func() {
+		if err := PostDocuments(url, docs); err != nil {
 			tracker.IncrementWithError(int64(len(docs)))
-			logger.Logger.Warn().Msgf("Failed to marshal docs: %v", err)
-			continue
+			logger.Logger.Warn().Msg(err.Error())
+		} else {
+			tracker.Increment(int64(len(docs)))
 		}
 
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+		docs = docs[:0]
+	}
+
+	for {
+		field, data, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			tracker.IncrementWithError(int64(len(docs)))
-			logger.Logger.Warn().Msgf("Failed to create request: %v", err)
+			tracker.MarkAsErrored()
+
+			var msg = fmt.Sprintf("Failed to read metadata %s: %v", inputMetadataPb, err)
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+		if field != constants.MetadataListItemsField {
 			continue
 		}
 
-		req.Header.Set("Content-Type", "application/json")
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			tracker.IncrementWithError(int64(len(docs)))
-			logger.Logger.Warn().Msgf("Failed to send request: %v", err)
+		item := &metadataproto.Metadata{}
+		if err := proto.Unmarshal(data, item); err != nil {
+			tracker.IncrementWithError(1)
+			logger.Logger.Warn().Msgf("Failed to unmarshal metadata item in %s: %v", inputMetadataPb, err)
 			continue
 		}
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				tracker.IncrementWithError(int64(len(docs)))
-				logger.Logger.Warn().Msgf("Failed to close response body: %v", err)
-			}
-		}(resp.Body)
 
-		if resp.StatusCode != http.StatusOK {
-			tracker.IncrementWithError(int64(len(docs)))
-			logger.Logger.Warn().Msgf("Failed to send request: status code %d", resp.StatusCode)
-			continue
+		if docs = append(docs, NewSolrDocument(item, summary, solrOpts)); len(docs) == constants.SolrBatchSize {
+			flush()
 		}
+	}
 
-		tracker.Increment(int64(len(docs)))
+	if len(docs) > 0 {
+		flush()
 	}
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"github.com/Rom1-J/preprocessor/app/populate/structs"
+	"github.com/Rom1-J/preprocessor/constants"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"slices"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func autofillItem() *metadataproto.Metadata {
+	return &metadataproto.Metadata{
+		Id: "autofill",
+		BrowserArtifacts: &metadataproto.BrowserArtifacts{
+			Autofill: []*metadataproto.AutofillField{
+				{Name: "email", Value: []byte("bob@example.org"), Record: 1},
+				{Name: "server", Value: []byte("10.0.0.1 intranet.example.org"), Record: 2},
+			},
+		},
+		SourcedEntities: []*metadataproto.SourcedEntity{
+			{
+				Type:   constants.EntityTypeEmail,
+				Value:  []byte("bob@example.org"),
+				Origin: metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL,
+				Source: "email",
+				Record: 1,
+			},
+			{
+				Type:   constants.EntityTypeIp,
+				Value:  []byte("10.0.0.1"),
+				Origin: metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL,
+				Source: "server",
+				Record: 2,
+			},
+			{
+				Type:   constants.EntityTypeDomain,
+				Value:  []byte("intranet.example.org"),
+				Origin: metadataproto.EntityOrigin_ENTITY_ORIGIN_AUTOFILL,
+				Source: "server",
+				Record: 2,
+			},
+		},
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestNewSolrDocumentKeepsAutofillValuesOut(t *testing.T) {
+	item := autofillItem()
+
+	for _, mode := range constants.BrowserArtifactsModes {
+		doc := NewSolrDocument(item, nil, structs.SolrOptsStruct{BrowserArtifacts: mode})
+
+		for field, value := range doc.Dynamic {
+			if !strings.HasPrefix(field, "autofill") {
+				continue
+			}
+
+			if mode != constants.BrowserArtifactsFull {
+				t.Errorf("mode %s: %s = %v, expected no autofill field", mode, field, value)
+				continue
+			}
+
+			types, _ := value.([]string)
+			for _, entity := range item.SourcedEntities {
+				if slices.Contains(types, string(entity.Value)) {
+					t.Errorf("mode %s: %s = %v, holds the value %s", mode, field, value, entity.Value)
+				}
+			}
+		}
+
+		if mode != constants.BrowserArtifactsFull && len(doc.SourcedColumns) > 0 {
+			t.Errorf("mode %s: sourced columns %v, expected none", mode, doc.SourcedColumns)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func TestNewSolrDocumentIndexesAutofillEntityTypes(t *testing.T) {
+	doc := NewSolrDocument(autofillItem(), nil, structs.SolrOptsStruct{BrowserArtifacts: constants.BrowserArtifactsFull})
+
+	for field, expected := range map[string][]string{
+		"autofill_field_email_ss":  {constants.EntityTypeEmail},
+		"autofill_field_server_ss": {constants.EntityTypeIp, constants.EntityTypeDomain},
+	} {
+		if types, _ := doc.Dynamic[field].([]string); !slices.Equal(types, expected) {
+			t.Errorf("%s = %v, expected %v", field, types, expected)
+		}
+	}
+}
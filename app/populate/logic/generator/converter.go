@@ -1,5 +1,10 @@
 package generator
 
+import (
+	"strings"
+	"time"
+)
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
@@ -12,3 +17,33 @@ func ConvertBytesToStrings(data [][]byte) []string {
 
 	return result
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func DynamicFieldName(prefix string, name string, suffix string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(name))
+
+	return prefix + "_" + name + "_" + suffix
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func EnumName(value string, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value, prefix))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SolrDate formats Unix seconds the way Solr date fields expect them, "" standing for 0, i.e. no date.
+func SolrDate(seconds uint64) string {
+	if seconds == 0 {
+		return ""
+	}
+
+	return time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+}
@@ -2,13 +2,18 @@ package populate
 
 import (
 	"context"
+	"fmt"
 	"github.com/Rom1-J/preprocessor/app/populate/logic"
 	"github.com/Rom1-J/preprocessor/app/populate/structs"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
 	ucli "github.com/urfave/cli/v3"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 )
 
@@ -67,6 +72,24 @@ func Action(ctx context.Context, command *ucli.Command) error {
 	logger.Logger.Debug().Msgf("Input files: %v", inputList)
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Privacy the collection holds, see extract --privacy-mode
+	//
+	privacy := &metadataproto.Privacy{KeyId: utils.KeyId([]byte(command.String("hmac-key")))}
+
+	if strings.ToLower(command.String("privacy-mode")) == constants.PrivacyModeHmac {
+		privacy.Mode = metadataproto.PrivacyMode_PRIVACY_MODE_HMAC
+
+		if privacy.KeyId == "" {
+			var msg = "--privacy-mode hmac requires --hmac-key"
+			logger.Logger.Error().Msg(msg)
+
+			return fmt.Errorf(msg)
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
 	// Initialize progress bar
@@ -104,9 +127,11 @@ func Action(ctx context.Context, command *ucli.Command) error {
 				globalProgress,
 				ipmpb,
 				structs.SolrOptsStruct{
-					Address:    command.StringSlice("solr")[i%len(command.StringSlice("solr"))],
-					Collection: command.String("collection"),
+					Address:          command.StringSlice("solr")[i%len(command.StringSlice("solr"))],
+					Collection:       command.String("collection"),
+					BrowserArtifacts: strings.ToLower(command.String("browser-artifacts")),
 				},
+				privacy,
 			); err != nil {
 				logger.Logger.Error().Msgf("Cannot ingest file '%s': %s", ipmpb, err)
 			}
@@ -1,18 +1,89 @@
 package structs
 
+import (
+	"encoding/json"
+)
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type SolrOptsStruct struct {
-	Address    string
-	Collection string
+	Address          string
+	Collection       string
+	BrowserArtifacts string // see --browser-artifacts
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type SolrDocument struct {
-	ID      string   `json:"id"`
-	Emails  []string `json:"emails"`
-	IPs     []string `json:"ips"`
-	Domains []string `json:"domains"`
+	ID            string   `json:"id"`
+	Emails        []string `json:"emails"`
+	IPs           []string `json:"ips"`
+	Domains       []string `json:"domains"`
+	Cards         []string `json:"cards"`
+	CardHashes    []string `json:"card_hashes"`
+	CardNetworks  []string `json:"card_networks"`
+	Ibans         []string `json:"ibans"`
+	IbanHashes    []string `json:"iban_hashes"`
+	IbanCountries []string `json:"iban_countries"`
+
+	HashAlgorithms []string `json:"hash_algorithms"`
+
+	SecretRules    []string `json:"secret_rules"`
+	SecretPreviews []string `json:"secret_previews"`
+	SecretHashes   []string `json:"secret_hashes"`
+	JwtIssuers     []string `json:"jwt_issuers"`
+	JwtSubjects    []string `json:"jwt_subjects"`
+
+	CredentialEmails []string `json:"credential_emails"`
+	PasswordKinds    []string `json:"password_kinds"`
+	PasswordHashes   []string `json:"password_hashes"`
+
+	IpClasses       []string `json:"ip_classes"`
+	IpCountries     []string `json:"ip_countries"`
+	IpAsns          []uint32 `json:"ip_asns"`
+	IpOrganizations []string `json:"ip_organizations"`
+
+	EmailCategories       []string `json:"email_categories"`
+	CorporateEmailDomains []string `json:"corporate_email_domains"`
+	RoleEmails            []string `json:"role_emails"`
+
+	Usernames      []string `json:"usernames"`
+	UsernameEmails []string `json:"username_emails"` // "username email" pairs of the same record
+
+	CookieDomains     []string `json:"cookie_domains"`
+	CookieCount       uint64   `json:"cookie_count"`
+	CookieNames       []string `json:"cookie_names"`
+	CookieValueHashes []string `json:"cookie_value_hashes"`
+	AutofillCount     uint64   `json:"autofill_count"`
+	AutofillNames     []string `json:"autofill_names"`
+
+	EarliestDate        string `json:"earliest_date,omitempty"`
+	LatestDate          string `json:"latest_date,omitempty"`
+	DatasetEarliestDate string `json:"dataset_earliest_date,omitempty"`
+	DatasetLatestDate   string `json:"dataset_latest_date,omitempty"`
+
+	SourcedColumns []string `json:"sourced_columns"`
+	SchemaFields   []string `json:"schema_fields"`
+
+	// Dynamic holds per-document Solr dynamic fields (e.g. "hashes_md5_l", "csv_email_ss"), flattened into the document on marshal.
+	Dynamic map[string]interface{} `json:"-"`
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (d SolrDocument) MarshalJSON() ([]byte, error) {
+	type plainSolrDocument SolrDocument
+
+	data, err := json.Marshal(plainSolrDocument(d))
+	if err != nil || len(d.Dynamic) == 0 {
+		return data, err
+	}
+
+	dynamic, err := json.Marshal(d.Dynamic)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(data[:len(data)-1], ','), dynamic[1:]...), nil
 }
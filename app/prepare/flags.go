@@ -1,8 +1,11 @@
 package prepare
 
 import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
 	ucli "github.com/urfave/cli/v3"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -51,4 +54,18 @@ var Flags = []ucli.Flag{
 		Value:    "dumpster",
 		Required: false,
 	},
+	&ucli.StringFlag{
+		Name:  "storage",
+		Usage: "How .compressed archives are stored: extracted (unpacked to disk) or archive (kept as is, entries indexed)",
+		Value: constants.StorageModeExtracted,
+		Validator: func(s string) error {
+			switch strings.ToLower(s) {
+			case
+				constants.StorageModeExtracted,
+				constants.StorageModeArchive:
+				return nil
+			}
+			return fmt.Errorf("expected one of %s, got: %s", strings.Join(constants.StorageModes, " or "), s)
+		},
+	},
 }
@@ -3,6 +3,7 @@ package logic
 import (
 	"fmt"
 	"github.com/Rom1-J/preprocessor/app/prepare/logic/generator"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/Rom1-J/preprocessor/pkg/prog"
 	infoproto "github.com/Rom1-J/preprocessor/proto/info"
@@ -79,7 +80,18 @@ func PrepareFile(
 	//
 	var metadataInfo *infoproto.MetadataInfo
 
-	if strings.HasSuffix(copiedFilePath, ".compressed") {
+	if strings.HasSuffix(copiedFilePath, ".compressed") && strings.ToLower(command.String("storage")) == constants.StorageModeArchive {
+		metadataInfo, err = generator.ProcessArchivedFile(id, command, copiedFilePath)
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to index archived file: %v", err)
+			logger.Logger.Error().Msg(msg)
+
+			globalProgress.Pw.Log(msg)
+			tracker.MarkAsErrored()
+
+			return nil, err
+		}
+	} else if strings.HasSuffix(copiedFilePath, ".compressed") {
 		metadataInfo, err = generator.ProcessCompressedFile(id, command, copiedFilePath)
 		if err != nil {
 			var msg = fmt.Sprintf("Failed to process compressed file: %v", err)
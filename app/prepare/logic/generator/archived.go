@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/archive"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	"github.com/google/uuid"
+	"github.com/segmentio/fasthash/fnv1a"
+	ucli "github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func streamSimhash(reader io.Reader) (uint64, error) {
+	hash := fnv1a.Init64
+	buffer := make([]byte, 1<<16)
+
+	for {
+		n, err := reader.Read(buffer)
+		hash = fnv1a.AddBytes64(hash, buffer[:n])
+
+		if err == io.EOF {
+			return hash, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProcessArchivedFile keeps the zstd tar as is and only indexes its entries, extract reads them back through the
+// frame index.
+func ProcessArchivedFile(id string, command *ucli.Command, inputFilePath string) (*infoproto.MetadataInfo, error) {
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Get file info
+	//
+	file, err := os.Open(inputFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	fileSimhash, err := streamSimhash(file)
+	_ = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	var metadata = infoproto.MetadataInfo{
+		Id:      id,
+		Bucket:  getBucketType(command),
+		Date:    command.String("date"),
+		Path:    []byte(filepath.Base(inputFilePath)),
+		Size:    uint64(fileInfo.Size()),
+		Simhash: fileSimhash,
+		Storage: infoproto.Storage_STORAGE_ARCHIVE,
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Index archive entries in a single pass
+	//
+	frames, err := archive.IndexZstdArchive(inputFilePath, func(entry archive.ZstdEntry, content io.Reader) error {
+		entrySimhash, err := streamSimhash(content)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Header.Name, err)
+		}
+
+		metadata.Children = append(metadata.Children, &infoproto.MetadataInfo{
+			Id:            uuid.New().String(),
+			Bucket:        metadata.Bucket,
+			Date:          metadata.Date,
+			Path:          []byte(filepath.Clean(entry.Header.Name)),
+			Size:          uint64(entry.Header.Size),
+			Simhash:       entrySimhash,
+			ArchiveOffset: entry.Offset,
+		})
+
+		return nil
+	})
+	if err != nil {
+		var msg = fmt.Sprintf("Failed to index %s: %v", inputFilePath, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	for _, frame := range frames {
+		metadata.Frames = append(metadata.Frames, &infoproto.ArchiveFrame{
+			CompressedOffset:   frame.CompressedOffset,
+			CompressedSize:     frame.CompressedSize,
+			DecompressedOffset: frame.DecompressedOffset,
+			DecompressedSize:   frame.DecompressedSize,
+		})
+	}
+
+	if len(frames) == 1 {
+		logger.Logger.Warn().Msgf("%s is a single zstd frame, every entry will be decoded from the start", inputFilePath)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return &metadata, nil
+}
@@ -3,12 +3,12 @@ package generator
 import (
 	"fmt"
 	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/office"
 	"github.com/Rom1-J/preprocessor/pkg/utils"
 	infoproto "github.com/Rom1-J/preprocessor/proto/info"
 	"github.com/google/uuid"
 	"github.com/segmentio/fasthash/fnv1a"
 	ucli "github.com/urfave/cli/v3"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -35,9 +35,8 @@ func getBucketType(command *ucli.Command) infoproto.Bucket {
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func GenerateForFile(id string, command *ucli.Command, inputFilePath string) (*infoproto.MetadataInfo, error) {
-	logger.Logger.Trace().Msgf("Generating metadata info for file %s", inputFilePath)
-
+// generateForLeaf describes the file itself, without splitting it.
+func generateForLeaf(id string, command *ucli.Command, inputFilePath string) (*infoproto.MetadataInfo, error) {
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
 	// Get file info
@@ -55,45 +54,99 @@ func GenerateForFile(id string, command *ucli.Command, inputFilePath string) (*i
 	fileSimhash := fnv1a.HashBytes64(fileData)
 	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
-	var metadata = infoproto.MetadataInfo{
+	return &infoproto.MetadataInfo{
 		Id:      id,
 		Bucket:  getBucketType(command),
 		Date:    command.String("date"),
 		Path:    []byte(filepath.Base(inputFilePath)),
 		Size:    uint64(fileSize),
 		Simhash: fileSimhash,
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func GenerateForFile(id string, command *ucli.Command, inputFilePath string) (*infoproto.MetadataInfo, error) {
+	logger.Logger.Trace().Msgf("Generating metadata info for file %s", inputFilePath)
+
+	metadata, err := generateForLeaf(id, command, inputFilePath)
+	if err != nil {
+		return nil, err
 	}
 
-	if utils.IsChunkable(inputFilePath) {
+	var splitDirPath string
+
+	switch {
+	case IsMailbox(inputFilePath), IsExplodable(inputFilePath):
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Split mailbox into messages, or message into headers and decoded parts
+		//
+		split := ExplodeMessage
+		if IsMailbox(inputFilePath) {
+			split = SplitMailbox
+		}
+
+		if splitDirPath, err = split(inputFilePath); err != nil {
+			// kept as a plain text file
+			var msg = fmt.Sprintf("Failed to split %s: %v", inputFilePath, err)
+			logger.Logger.Warn().Msg(msg)
+
+			splitDirPath = ""
+		}
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	case office.IsSpreadsheet(inputFilePath), office.IsDocument(inputFilePath):
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Convert spreadsheet into CSVs, or document into text, the original being moved next to them
+		//
+		if splitDirPath, err = ConvertOfficeFile(inputFilePath); err != nil {
+			// kept as an unreadable file
+			var msg = fmt.Sprintf("Failed to convert %s: %v", inputFilePath, err)
+			logger.Logger.Warn().Msg(msg)
+
+			splitDirPath = ""
+		}
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	case utils.IsChunkable(inputFilePath):
 		logger.Logger.Trace().Msgf("File %s too big, chunking it", inputFilePath)
 
 		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 		//
 		// Chunkify file
 		//
-		chunkedDirPath, err := utils.Chunkify(inputFilePath)
-		if err != nil {
+		if splitDirPath, err = utils.Chunkify(inputFilePath); err != nil {
 			var msg = fmt.Sprintf("Failed to chunkify file %s: %v", inputFilePath, err)
 			logger.Logger.Error().Msg(msg)
 
 			return nil, fmt.Errorf(msg)
 		}
 		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+	}
 
+	if splitDirPath != "" {
 		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 		//
-		// Update metadata Path as .chunked
+		// Update metadata Path as .chunked, .messages, .parts or .converted
 		//
-		metadata.Path = []byte(filepath.Base(chunkedDirPath))
+		metadata.Path = []byte(filepath.Base(splitDirPath))
 		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 		//
-		// Delete old file
+		// Delete old file, unless moved next to its conversions
 		//
-		if err := os.Remove(inputFilePath); err != nil {
-			var msg = fmt.Sprintf("Failed to remove %s: %v", inputFilePath, err)
-			logger.Logger.Warn().Msg(msg)
+		originalPath := filepath.Join(splitDirPath, filepath.Base(inputFilePath))
+		_, statErr := os.Stat(originalPath)
+		keptOriginal := statErr == nil
+
+		if !keptOriginal {
+			if err := os.Remove(inputFilePath); err != nil {
+				var msg = fmt.Sprintf("Failed to remove %s: %v", inputFilePath, err)
+				logger.Logger.Warn().Msg(msg)
+			}
 		}
 		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
@@ -101,27 +154,35 @@ func GenerateForFile(id string, command *ucli.Command, inputFilePath string) (*i
 		//
 		// Get metadata for new files
 		//
-		if err := filepath.WalkDir(chunkedDirPath, func(path string, d fs.DirEntry, err error) error {
-			if _, err := os.Stat(path); os.IsNotExist(err) || d.IsDir() {
-				return nil
+		// listed beforehand, messages attached to messages being split in turn
+		entries, err := os.ReadDir(splitDirPath)
+		if err != nil {
+			var msg = fmt.Sprintf("Failed to read %s: %v", splitDirPath, err)
+			logger.Logger.Warn().Msg(msg)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
 			}
 
-			partMetadata, err := GenerateForFile(uuid.New().String(), command, path)
+			var partMetadata *infoproto.MetadataInfo
+
+			if keptOriginal && entry.Name() == filepath.Base(inputFilePath) {
+				partMetadata, err = generateForLeaf(uuid.New().String(), command, originalPath)
+			} else {
+				partMetadata, err = GenerateForFile(uuid.New().String(), command, filepath.Join(splitDirPath, entry.Name()))
+			}
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			metadata.Children = append(metadata.Children, partMetadata)
-
-			return nil
-		}); err != nil {
-			var msg = fmt.Sprintf("Failed to walk %s: %v", chunkedDirPath, err)
-			logger.Logger.Warn().Msg(msg)
 		}
 		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 	}
 
-	return &metadata, nil
+	return metadata, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
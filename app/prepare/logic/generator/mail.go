@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/mailbox"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsMailbox(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == constants.MailboxExtension
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsExplodable tells whether the message is split into its headers and decoded parts, the headers file itself
+// being kept as is.
+func IsExplodable(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == constants.MessageExtension &&
+		filepath.Base(path) != constants.MessageHeadersFileName
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SplitMailbox writes each message of the mbox as "<path>.messages/000001.eml"...
+func SplitMailbox(path string) (string, error) {
+	logger.Logger.Trace().Msgf("Start mailbox splitting on: %s", path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close mailbox: %v", err)
+		}
+	}()
+
+	outputDirectory := path + constants.MailboxMessagesSuffix
+	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
+		return "", err
+	}
+
+	count := 0
+
+	if err := mailbox.SplitMbox(file, func(message []byte) error {
+		count++
+
+		return os.WriteFile(
+			filepath.Join(outputDirectory, fmt.Sprintf("%06d%s", count, constants.MessageExtension)),
+			message,
+			0644,
+		)
+	}); err != nil {
+		return "", err
+	}
+
+	logger.Logger.Debug().Msgf("Mailbox %s split into %d messages", path, count)
+
+	return outputDirectory, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExplodeMessage writes the raw headers of the message as "<path>.parts/headers.eml", and each decoded part next
+// to it, e.g. "part-01.txt", "part-02.html" or "part-03-invoice.pdf".
+func ExplodeMessage(path string) (string, error) {
+	logger.Logger.Trace().Msgf("Start message decoding on: %s", path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close message: %v", err)
+		}
+	}()
+
+	reader := bufio.NewReader(file)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Write headers
+	//
+	headerBlock, err := mailbox.ReadHeaderBlock(reader)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(headerBlock))
+	if err != nil {
+		return "", fmt.Errorf("invalid message headers: %v", err)
+	}
+
+	outputDirectory := path + constants.MessagePartsSuffix
+	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDirectory, constants.MessageHeadersFileName), headerBlock, 0644); err != nil {
+		return "", err
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Write decoded parts
+	//
+	if err := mailbox.WalkParts(message.Header, reader, func(part mailbox.Part) error {
+		output, err := os.Create(filepath.Join(outputDirectory, part.SafeFileName()))
+		if err != nil {
+			return err
+		}
+
+		body := part.Body
+		if part.IsText() {
+			body = part.Text()
+		}
+
+		_, err = io.Copy(output, body)
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+
+		return err
+	}); err != nil {
+		// parts decoded so far are kept, e.g. for a truncated message
+		logger.Logger.Warn().Msgf("Failed to decode parts of %s: %v", path, err)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return outputDirectory, nil
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/Rom1-J/preprocessor/pkg/office"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var sheetNameRegex = regexp.MustCompile(`[^\w.-]+`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConvertOfficeFile writes "<path>.converted/sheet-01-<name>.csv"... for spreadsheets or
+// "<path>.converted/document.txt" for documents, and moves the original next to them.
+func ConvertOfficeFile(path string) (string, error) {
+	logger.Logger.Trace().Msgf("Start office conversion on: %s", path)
+
+	outputDirectory := path + constants.OfficeConvertedSuffix
+	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
+		return "", err
+	}
+
+	var err error
+
+	if office.IsSpreadsheet(path) {
+		err = convertSpreadsheet(path, outputDirectory)
+	} else {
+		err = convertDocument(path, outputDirectory)
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(outputDirectory)
+
+		return "", err
+	}
+
+	if err := os.Rename(path, filepath.Join(outputDirectory, filepath.Base(path))); err != nil {
+		return "", err
+	}
+
+	return outputDirectory, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func convertSpreadsheet(path string, outputDirectory string) error {
+	var files []*os.File
+
+	defer func() {
+		for _, file := range files {
+			if err := file.Close(); err != nil {
+				logger.Logger.Error().Msgf("Failed to close sheet: %v", err)
+			}
+		}
+	}()
+
+	return office.ReadSpreadsheet(path, func(index int, name string) (*csv.Writer, error) {
+		name = strings.Trim(sheetNameRegex.ReplaceAllString(name, "_"), "._")
+
+		fileName := fmt.Sprintf("sheet-%02d.csv", index)
+		if name != "" {
+			fileName = fmt.Sprintf("sheet-%02d-%s.csv", index, name)
+		}
+
+		file, err := os.Create(filepath.Join(outputDirectory, fileName))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+
+		return csv.NewWriter(file), nil
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func convertDocument(path string, outputDirectory string) error {
+	file, err := os.Create(filepath.Join(outputDirectory, constants.OfficeDocumentFileName))
+	if err != nil {
+		return err
+	}
+
+	err = office.ReadDocument(path, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
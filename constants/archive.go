@@ -0,0 +1,15 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	StorageModeExtracted = "extracted"
+	StorageModeArchive   = "archive"
+)
+
+var StorageModes = []string{StorageModeExtracted, StorageModeArchive}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const ArchiveFrameSize = 1 << 22 // 4MiB of tar per zstd frame, the granularity of random access
@@ -0,0 +1,31 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CookieLinePattern matches the 7 tab-separated fields of Netscape cookie files: domain, include subdomains, path,
+// secure, expiry, name and value, "#HttpOnly_" prefixing the domain of HTTP-only cookies.
+var CookieLinePattern = regexp.MustCompile(
+	`(?i)^(#HttpOnly_)?([^\s#][^\t]*)\t(?:TRUE|FALSE)\t[^\t]*\t(TRUE|FALSE)\t(-?\d+(?:\.\d+)?)\t([^\t]*)\t([^\r\n]*)`,
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AutofillNamePattern and AutofillValuePattern match the "Name: email" / "Value: john@example.com" line pairs of
+// stealer autofill dumps.
+var AutofillNamePattern = regexp.MustCompile(`(?i)^\s*(?:name|field|form(?:\s*name)?)\s*:\s?(.*?)\s*$`)
+var AutofillValuePattern = regexp.MustCompile(`(?i)^\s*value\s*:\s?(.*?)\s*$`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	BrowserArtifactsNone   = "none"   // nothing indexed
+	BrowserArtifactsCounts = "counts" // cookie domains and cookie/autofill counts
+	BrowserArtifactsFull   = "full"   // cookie names and value hashes, autofill names and entity types as well
+)
+
+var BrowserArtifactsModes = []string{BrowserArtifactsNone, BrowserArtifactsCounts, BrowserArtifactsFull}
@@ -0,0 +1,55 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	EntityTypeEmail     = "email"
+	EntityTypeIp        = "ip"
+	EntityTypeDomain    = "domain"
+	EntityTypePhone     = "phone"
+	EntityTypeUsername  = "username"
+	EntityTypePassword  = "password"
+	EntityTypeUrl       = "url"
+	EntityTypeTimestamp = "timestamp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ColumnRule struct {
+	Type    string
+	Pattern *regexp.Regexp // matched against the normalized header name, e.g. "Last Login-IP" -> "last_login_ip"
+}
+
+// ColumnDictionary maps CSV header names to entity types, the first matching rule wins.
+var ColumnDictionary = []ColumnRule{
+	{Type: EntityTypeEmail, Pattern: regexp.MustCompile(`^(?:\w+_)?e_?mail(?:_?addr(?:ess)?)?$|^mail$`)},
+	{Type: EntityTypePassword, Pattern: regexp.MustCompile(`^(?:\w+_)?(?:password|passwd|pass|pwd)(?:_?hash)?$|^hash$`)},
+	{Type: EntityTypeIp, Pattern: regexp.MustCompile(`^(?:\w+_)?ip(?:_?addr(?:ess)?)?(?:_?v[46])?$|^ip_\w+$`)},
+	{Type: EntityTypePhone, Pattern: regexp.MustCompile(`^(?:\w+_)?(?:phone|mobile|tel|telephone|msisdn|cell)(?:_?(?:number|num|no))?$`)},
+	{Type: EntityTypeTimestamp, Pattern: regexp.MustCompile(`^(?:date|time|datetime|timestamp)$|^\w+_(?:datetime|timestamp)$|` +
+		`^(?:created|updated|modified|registered|joined|signup|posted|inserted|login)_?(?:at|on|date|time|ts)$|` +
+		`^(?:created|updated|modified|registered|last_(?:login|seen|visit|activity))(?:_?(?:at|on|date|time))?$|` +
+		`^(?:date|time)_(?:created|updated|modified|registered|joined|added|posted)$`)},
+	{Type: EntityTypeUsername, Pattern: regexp.MustCompile(`^(?:\w+_)?(?:user_?name|login|nick(?:name)?|handle|screen_?name|pseudo)$|^uid$`)},
+	{Type: EntityTypeDomain, Pattern: regexp.MustCompile(`^(?:\w+_)?(?:domain|host(?:name)?|website|site|url)$`)},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var CsvDelimiters = []rune{',', ';', '\t', '|'}
+
+const (
+	CsvSniffSize  = 64 << 10 // bytes peeked at the start of a file to detect its dialect
+	CsvSniffLines = 20
+	// CsvSniffRatio is the share of sampled rows that must have as many fields as the header.
+	CsvSniffRatio = 0.8
+)
+
+var PhoneSeparatorPattern = regexp.MustCompile(`[\s().-]`)
+
+var PhonePattern = regexp.MustCompile(`^\+?\d{7,15}$`)
@@ -0,0 +1,25 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	PasswordModeClassify = "classify"
+	PasswordModeHash     = "hash"
+)
+
+var PasswordModes = []string{PasswordModeClassify, PasswordModeHash}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CombolistPattern matches "email:password" lines, the separator being the first one following the address.
+var CombolistPattern = regexp.MustCompile(`^\s*` + EmailPattern.String() + `\s*[:;|\t]([^\r\n]*?)\s*$`)
+
+// LoginLinePattern & PasswordLinePattern match stealer-log style blocks, with the password on the next line.
+var LoginLinePattern = regexp.MustCompile(`(?i)^\s*(?:login|user(?:name)?|e-?mail)\s*[:=]\s*` + EmailPattern.String() + `\s*$`)
+
+var PasswordLinePattern = regexp.MustCompile(`(?i)^\s*(?:password|passwd|pass|pwd)\s*[:=][ \t]?([^\r\n]*?)\s*$`)
@@ -0,0 +1,47 @@
+package constants
+
+import (
+	"regexp"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DateMinimum and DateFutureSlack bound the timestamps folded into the date range of a part, older or later ones
+// being rather birth dates, expiries or noise.
+var DateMinimum = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const DateFutureSlack = 24 * time.Hour
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var DateMonths = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// The date patterns are anchored at the candidates DateRange finds, their groups being year, month, day, hour, minute,
+// second and zone for IsoDatePattern, day, month, year, hour, minute, second and zone for the others.
+var (
+	// IsoDatePattern matches ISO 8601 "2019-05-04T10:00:00.123Z" and Nginx error log "2019/05/04 10:00:00" dates.
+	IsoDatePattern = regexp.MustCompile(
+		`^(\d{4})[-/](\d{2})[-/](\d{2})(?:[T ](\d{2}):(\d{2})(?::(\d{2}))?(?:[.,]\d+)? ?(Z|[+-]\d{2}:?\d{2})?)?`,
+	)
+	// Rfc2822DatePattern matches mail "Date: Tue, 1 Jul 2003 10:52:37 +0200" dates, day name left out.
+	Rfc2822DatePattern = regexp.MustCompile(
+		`^(\d{1,2}) ([A-Za-z]{3}) (\d{4}),? (\d{2}):(\d{2})(?::(\d{2}))?(?: ([+-]\d{4}|UTC?|GMT|Z))?`,
+	)
+	// AccessLogDatePattern matches Apache and Nginx access log "[10/Oct/2000:13:55:36 -0700]" dates.
+	AccessLogDatePattern = regexp.MustCompile(
+		`^(\d{2})/([A-Za-z]{3})/(\d{4}):(\d{2}):(\d{2}):(\d{2}) ([+-]\d{4})`,
+	)
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EpochPattern matches the Unix epochs of timestamp columns, in seconds, milliseconds, microseconds or nanoseconds.
+var EpochPattern = regexp.MustCompile(`^(\d{9,10}|\d{12,13}|\d{15,16}|\d{18,19})(?:\.\d+)?$`)
@@ -0,0 +1,27 @@
+package constants
+
+import (
+	_ "embed"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// FreemailDomains and DisposableDomains are the embedded provider lists, one domain per line, # comments.
+var (
+	//go:embed lists/freemail.txt
+	FreemailDomains string
+
+	//go:embed lists/disposable.txt
+	DisposableDomains string
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EmailRoleLocalParts flag the addresses of a function rather than a person, "+tag" suffixes left aside.
+var EmailRoleLocalParts = []string{
+	"abuse", "accounting", "accounts", "admin", "administrator", "billing", "careers", "contact", "do-not-reply",
+	"donotreply", "help", "helpdesk", "hostmaster", "hr", "info", "jobs", "mail", "mailer-daemon", "marketing",
+	"news", "newsletter", "no-reply", "noc", "noreply", "notifications", "office", "postmaster", "press", "privacy",
+	"root", "sales", "security", "service", "support", "team", "webmaster",
+}
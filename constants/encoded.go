@@ -0,0 +1,29 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	EncodingBase64  = "base64"
+	EncodingHex     = "hex"
+	EncodingPercent = "percent"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	EncodedMinBase64Length = 24 // encodes 18 bytes, e.g. "a.b@mail.com:pass12"
+	EncodedMinHexLength    = 32
+	EncodedMaxDepth        = 3       // base64 in percent-encoding in base64
+	EncodedMaxSize         = 1 << 20 // decoded bytes per scanned text, nested blobs included
+	// EncodedPrintableRatio is the share of printable runes a decoded blob needs to be scanned, hashes and keys
+	// decode to binary and are left alone.
+	EncodedPrintableRatio = 0.95
+)
+
+// PercentEncodedPattern matches a run holding at least one %XX escape, e.g. "email=john%40example.com&pass=...".
+var PercentEncodedPattern = regexp.MustCompile(`[^\s"'<>]*%[0-9A-Fa-f]{2}[^\s"'<>]*`)
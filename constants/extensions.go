@@ -5,7 +5,7 @@ var TextFilesExtensions = []string{
 	".c", ".h", ".cpp", ".hpp", ".java", ".py", ".sh", ".bat", ".cmd", ".ps1",
 	".pl", ".rb", ".php", ".go", ".rs", ".js", ".ts", ".jsx", ".tsx",
 	".csv", ".tsv", ".ini", ".conf", ".cfg", ".env",
-	".sql", ".psql", ".dump",
+	".sql", ".psql", ".dump", ".ldif", ".vcf", ".vcard", ".eml", ".mbox",
 	".html", ".htm", ".xhtml", ".css", ".tex", ".bib",
 	".gitignore", ".gitattributes", ".patch", ".diff",
 	".manifest", ".license", ".readme", ".todo", ".nfo",
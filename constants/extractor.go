@@ -0,0 +1,8 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExtractorVersion is stored in every Metadata, bump it whenever extractors change what they emit so that
+// `extract --incremental` redoes the parts extracted by an older version.
+const ExtractorVersion = 16
@@ -0,0 +1,48 @@
+package constants
+
+import "regexp"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var CardPattern = regexp.MustCompile(`\b\d+(?:[ -]\d+)*\b`)
+
+var CardSeparatorPattern = regexp.MustCompile(`[ -]`)
+
+var IbanPattern = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IbanLengths maps ISO 3166 country codes to their ISO 13616 IBAN length.
+var IbanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type CardNetwork struct {
+	Name     string
+	Prefixes [][2]int
+	Lengths  []int
+}
+
+// CardNetworks is ordered from the most to the least specific prefixes, the first match wins.
+var CardNetworks = []CardNetwork{
+	{Name: "amex", Prefixes: [][2]int{{34, 34}, {37, 37}}, Lengths: []int{15}},
+	{Name: "diners", Prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, Lengths: []int{14, 15, 16, 17, 18, 19}},
+	{Name: "jcb", Prefixes: [][2]int{{3528, 3589}}, Lengths: []int{16, 17, 18, 19}},
+	{Name: "mastercard", Prefixes: [][2]int{{51, 55}, {2221, 2720}}, Lengths: []int{16}},
+	{Name: "discover", Prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, Lengths: []int{16, 17, 18, 19}},
+	{Name: "unionpay", Prefixes: [][2]int{{62, 62}}, Lengths: []int{16, 17, 18, 19}},
+	{Name: "visa", Prefixes: [][2]int{{4, 4}}, Lengths: []int{13, 16, 19}},
+	// issued ranges only, "5" or "6" and Luhn alone would take one in ten 12 to 19 digits numbers for a card
+	{Name: "maestro", Prefixes: [][2]int{{5018, 5018}, {5020, 5020}, {5038, 5038}, {5893, 5893}, {6304, 6304}, {6759, 6759}, {6761, 6763}}, Lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
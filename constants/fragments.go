@@ -26,7 +26,10 @@ func sortedTLDPattern(allTlds []string) []string {
 	return allTlds
 }
 
-var DomainPattern = regexp.MustCompile(`(?i)([a-zA-Z0-9-.]{1,253}\.(?:` + strings.Join(sortedTLDPattern(append(tlds, extraTlds...)), "|") + `)\b)`)
+// Tlds is ordered longest first, so the DomainPattern alternation always prefers the longest matching TLD.
+var Tlds = sortedTLDPattern(append(tlds, extraTlds...))
+
+var DomainPattern = regexp.MustCompile(`(?i)([a-zA-Z0-9-.]{1,253}\.(?:` + strings.Join(Tlds, "|") + `)\b)`)
 
 var ipv4Pattern = regexp.MustCompile(`(((25[0-5]|2[0-4]\d|1\d{2}|0?\d{1,2})\b\.){3}(25[0-5]|2[0-4]\d|1\d{2}|0?\d{1,2})\b)`)
 
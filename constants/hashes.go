@@ -0,0 +1,77 @@
+package constants
+
+import (
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type HashFormat struct {
+	Algorithm string
+	Pattern   *regexp.Regexp
+}
+
+// HashFormats are self-describing formats, a match is always HASH_CONFIDENCE_CERTAIN.
+var HashFormats = []HashFormat{
+	{Algorithm: "bcrypt", Pattern: regexp.MustCompile(`\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}`)},
+	{Algorithm: "argon2", Pattern: regexp.MustCompile(`\$argon2(?:id|i|d)\$v=\d+\$m=\d+,t=\d+,p=\d+\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+`)},
+	{Algorithm: "pbkdf2", Pattern: regexp.MustCompile(`(?:\$pbkdf2(?:-sha1|-sha256|-sha512)?\$\d+\$[./A-Za-z0-9+=]+\$[./A-Za-z0-9+=]+|pbkdf2_sha(?:1|256|512)\$\d+\$[A-Za-z0-9]+\$[A-Za-z0-9+/=]+)`)},
+	{Algorithm: "sha512crypt", Pattern: regexp.MustCompile(`\$6\$(?:rounds=\d+\$)?[./A-Za-z0-9]{1,16}\$[./A-Za-z0-9]{86}`)},
+	{Algorithm: "sha256crypt", Pattern: regexp.MustCompile(`\$5\$(?:rounds=\d+\$)?[./A-Za-z0-9]{1,16}\$[./A-Za-z0-9]{43}`)},
+	{Algorithm: "md5crypt", Pattern: regexp.MustCompile(`\$(?:1|apr1)\$[./A-Za-z0-9]{1,8}\$[./A-Za-z0-9]{22}`)},
+	{Algorithm: "mysql5", Pattern: regexp.MustCompile(`\*[0-9A-F]{40}\b`)},
+	{Algorithm: "ldap", Pattern: regexp.MustCompile(`\{(?:SSHA512|SSHA256|SSHA|SHA|SMD5|MD5)\}[A-Za-z0-9+/]+={0,2}`)},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var HexHashPattern = regexp.MustCompile(`\b(?:[0-9a-f]{32,128}|[0-9A-F]{32,128})\b`)
+
+var NtlmContextPattern = regexp.MustCompile(`(?i)(?:ntlm|nthash|nt_hash)`)
+
+// PwdumpPattern matches "user:rid:LM:NT:::" records, where both digests are known for sure.
+var PwdumpPattern = regexp.MustCompile(`:\d+:([0-9A-Fa-f]{32}):([0-9A-Fa-f]{32}):::`)
+
+const EmptyLmHash = "aad3b435b51404eeaad3b435b51404ee"
+
+type HexHashType struct {
+	Algorithm    string
+	Confidence   metadataproto.HashConfidence
+	Alternatives []string
+}
+
+// HexHashLengths are raw hex digests, where the length alone only hints at the algorithm.
+var HexHashLengths = map[int]HexHashType{
+	32: {
+		Algorithm:    "md5",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_AMBIGUOUS,
+		Alternatives: []string{"ntlm", "md4", "lm"},
+	},
+	40: {
+		Algorithm:    "sha1",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_LIKELY,
+		Alternatives: []string{"ripemd160"},
+	},
+	56: {
+		Algorithm:    "sha224",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_AMBIGUOUS,
+		Alternatives: []string{"sha3-224"},
+	},
+	64: {
+		Algorithm:    "sha256",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_LIKELY,
+		Alternatives: []string{"sha3-256", "blake2s"},
+	},
+	96: {
+		Algorithm:    "sha384",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_LIKELY,
+		Alternatives: []string{"sha3-384"},
+	},
+	128: {
+		Algorithm:    "sha512",
+		Confidence:   metadataproto.HashConfidence_HASH_CONFIDENCE_LIKELY,
+		Alternatives: []string{"sha3-512", "blake2b", "whirlpool"},
+	},
+}
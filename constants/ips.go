@@ -0,0 +1,78 @@
+package constants
+
+import (
+	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"net/netip"
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MmdbMetadataMarker       = "\xab\xcd\xefMaxMind.com"
+	MmdbDataSectionSeparator = 16
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type IpClassRange struct {
+	Class  metadataproto.IpClass
+	Prefix netip.Prefix
+}
+
+// IpClassRanges are the special-purpose ranges of RFC 6890 and its updates, IPs outside of them being public.
+var IpClassRanges = []IpClassRange{
+	{Class: metadataproto.IpClass_IP_CLASS_PRIVATE, Prefix: netip.MustParsePrefix("10.0.0.0/8")},
+	{Class: metadataproto.IpClass_IP_CLASS_PRIVATE, Prefix: netip.MustParsePrefix("172.16.0.0/12")},
+	{Class: metadataproto.IpClass_IP_CLASS_PRIVATE, Prefix: netip.MustParsePrefix("192.168.0.0/16")},
+	{Class: metadataproto.IpClass_IP_CLASS_PRIVATE, Prefix: netip.MustParsePrefix("fc00::/7")},
+	{Class: metadataproto.IpClass_IP_CLASS_CGNAT, Prefix: netip.MustParsePrefix("100.64.0.0/10")},
+	{Class: metadataproto.IpClass_IP_CLASS_LOOPBACK, Prefix: netip.MustParsePrefix("127.0.0.0/8")},
+	{Class: metadataproto.IpClass_IP_CLASS_LOOPBACK, Prefix: netip.MustParsePrefix("::1/128")},
+	{Class: metadataproto.IpClass_IP_CLASS_LINK_LOCAL, Prefix: netip.MustParsePrefix("169.254.0.0/16")},
+	{Class: metadataproto.IpClass_IP_CLASS_LINK_LOCAL, Prefix: netip.MustParsePrefix("fe80::/10")},
+	{Class: metadataproto.IpClass_IP_CLASS_MULTICAST, Prefix: netip.MustParsePrefix("224.0.0.0/4")},
+	{Class: metadataproto.IpClass_IP_CLASS_MULTICAST, Prefix: netip.MustParsePrefix("ff00::/8")},
+	{Class: metadataproto.IpClass_IP_CLASS_DOCUMENTATION, Prefix: netip.MustParsePrefix("192.0.2.0/24")},
+	{Class: metadataproto.IpClass_IP_CLASS_DOCUMENTATION, Prefix: netip.MustParsePrefix("198.51.100.0/24")},
+	{Class: metadataproto.IpClass_IP_CLASS_DOCUMENTATION, Prefix: netip.MustParsePrefix("203.0.113.0/24")},
+	{Class: metadataproto.IpClass_IP_CLASS_DOCUMENTATION, Prefix: netip.MustParsePrefix("2001:db8::/32")},
+	{Class: metadataproto.IpClass_IP_CLASS_RESERVED, Prefix: netip.MustParsePrefix("0.0.0.0/8")},
+	{Class: metadataproto.IpClass_IP_CLASS_RESERVED, Prefix: netip.MustParsePrefix("192.0.0.0/24")},
+	{Class: metadataproto.IpClass_IP_CLASS_RESERVED, Prefix: netip.MustParsePrefix("198.18.0.0/15")},
+	{Class: metadataproto.IpClass_IP_CLASS_RESERVED, Prefix: netip.MustParsePrefix("240.0.0.0/4")},
+	{Class: metadataproto.IpClass_IP_CLASS_RESERVED, Prefix: netip.MustParsePrefix("::/128")},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IpClassNames are the --drop-ip-classes values.
+var IpClassNames = map[string]metadataproto.IpClass{
+	"public":        metadataproto.IpClass_IP_CLASS_PUBLIC,
+	"private":       metadataproto.IpClass_IP_CLASS_PRIVATE,
+	"cgnat":         metadataproto.IpClass_IP_CLASS_CGNAT,
+	"reserved":      metadataproto.IpClass_IP_CLASS_RESERVED,
+	"loopback":      metadataproto.IpClass_IP_CLASS_LOOPBACK,
+	"link-local":    metadataproto.IpClass_IP_CLASS_LINK_LOCAL,
+	"multicast":     metadataproto.IpClass_IP_CLASS_MULTICAST,
+	"documentation": metadataproto.IpClass_IP_CLASS_DOCUMENTATION,
+}
+
+const (
+	IpClassesDropNone = "none"
+	IpClassVersion    = "version" // not an address range, "version 1.2.3.4" like version numbers
+)
+
+// IpClassesNoise are the classes worth dropping from search, e.g. --drop-ip-classes with all of them, every IP being
+// kept by default.
+var IpClassesNoise = []string{"loopback", "link-local", "multicast", "documentation", "reserved", IpClassVersion}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IpVersionContextPattern matches the end of the text preceding a "version 1.2.3.4" like version number, which the
+// IP pattern cannot tell from an IPv4.
+var IpVersionContextPattern = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:version|ver|build|release|rev|v)[ \t]*[:=#]?[ \t]*$`)
+
+// IpVersionContextLength bounds the text IpVersionContextPattern looks back at.
+const IpVersionContextLength = 16
@@ -0,0 +1,43 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MailboxExtension = ".mbox"
+	MessageExtension = ".eml"
+
+	MailboxMessagesSuffix  = ".messages" // prepare splits "x.mbox" into "x.mbox.messages/000001.eml"...
+	MessagePartsSuffix     = ".parts"    // and "y.eml" into "y.eml.parts/headers.eml", "part-01.txt"...
+	MessageHeadersFileName = "headers.eml"
+)
+
+// MboxFromPrefix starts every message of an mbox, the same prefix in bodies being escaped as ">From ".
+const MboxFromPrefix = "From "
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MailAddressHeaders are parsed as address lists, their addresses being sourced from the header name.
+var MailAddressHeaders = []string{
+	"From", "Sender", "Reply-To", "To", "Cc", "Bcc", "Return-Path", "Delivered-To", "X-Original-To",
+}
+
+// MailRelayHeaders are scanned as text for the hosts and IPs messages went through.
+var MailRelayHeaders = []string{
+	"Received", "X-Originating-IP", "X-Sender-IP", "X-Remote-IP",
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MimeExtensions names the decoded parts of messages, other types keep their attachment extension or get ".bin".
+var MimeExtensions = map[string]string{
+	"text/plain":       ".txt",
+	"text/html":        ".html",
+	"text/csv":         ".csv",
+	"text/xml":         ".xml",
+	"text/vcard":       ".vcf",
+	"text/x-vcard":     ".vcf",
+	"application/json": ".json",
+	"application/xml":  ".xml",
+	"message/rfc822":   ".eml",
+}
@@ -0,0 +1,34 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var MarkupHtmlExtensions = []string{".html", ".htm", ".xhtml"}
+var MarkupXmlExtensions = []string{".xml"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkupLinkAttributes hold URLs, harvested as "url" entities besides the emails, hosts and IPs found in them.
+var MarkupLinkAttributes = []string{"href", "src", "action", "formaction", "cite", "poster", "background", "data"}
+
+// MarkupTextAttributes of HTML are scanned as text, "data-*" ones as well, other HTML attributes being styling or
+// scripting noise. Every attribute of XML is scanned but namespace declarations.
+var MarkupTextAttributes = []string{"content", "value", "title", "alt", "placeholder", "label"}
+
+// MarkupUrlSchemes are the schemes of harvested URLs, "mailto:" links yielding emails instead.
+var MarkupUrlSchemes = []string{"http", "https", "ftp", "ftps", "ws", "wss"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkupBlockTags end a line of visible text, so that table cells and paragraphs are not glued together.
+var MarkupBlockTags = []string{
+	"address", "article", "aside", "blockquote", "br", "dd", "div", "dl", "dt", "fieldset", "figcaption", "figure",
+	"footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "option", "p",
+	"pre", "section", "table", "title", "tr", "ul",
+}
+
+// MarkupCellTags are separated by tabs.
+var MarkupCellTags = []string{"td", "th"}
+
+// MarkupRawTags hold no visible text, scripts being still scanned for secrets, JSON ones as text.
+var MarkupRawTags = []string{"script", "style"}
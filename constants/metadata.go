@@ -0,0 +1,13 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MetadataList fields, see proto/metadata/metadata.proto. Extract streams the privacy and the options fingerprint
+// first, then the items, the summary last.
+const (
+	MetadataListItemsField   = 1
+	MetadataListSummaryField = 2
+	MetadataListPrivacyField = 3
+	MetadataListOptionsField = 4
+)
@@ -0,0 +1,28 @@
+package constants
+
+import (
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BucketNames are the --noise-filter "buckets" values, named after the prepare --bucket ones.
+var BucketNames = map[string]infoproto.Bucket{
+	"dumpster":        infoproto.Bucket_DUMPSTER,
+	"leaks.logs":      infoproto.Bucket_LEAKS_LOGS,
+	"leaks.databases": infoproto.Bucket_LEAKS_DATABASES,
+	"combinations":    infoproto.Bucket_COMBINATIONS,
+	"pastes":          infoproto.Bucket_PASTES,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	NoiseMatchExact  = "exact"
+	NoiseMatchSuffix = "suffix" // the domain itself or any subdomain, that of the address for emails
+	NoiseMatchRegex  = "regex"
+)
+
+// NoiseEntityTypes are the entity types --noise-filter entries apply to, every one of them when an entry has none.
+var NoiseEntityTypes = []string{EntityTypeDomain, EntityTypeEmail}
@@ -0,0 +1,16 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OfficeSpreadsheetExtensions are converted to one CSV per sheet, OfficeDocumentExtensions to plain text.
+var OfficeSpreadsheetExtensions = []string{".xlsx", ".xlsm", ".ods"}
+var OfficeDocumentExtensions = []string{".docx", ".docm", ".pptx", ".odt", ".odp"}
+
+const (
+	OfficeConvertedSuffix  = ".converted" // prepare moves "x.xlsx" to "x.xlsx.converted/x.xlsx" next to its CSVs
+	OfficeDocumentFileName = "document.txt"
+	OfficeMaxEntrySize     = 1 << 28 // 256MiB of XML per zip entry, larger ones look like zip bombs
+	OfficeMaxRepeatedCells = 1 << 10 // OpenDocument repeats of a non-empty cell or row, e.g. a filled column
+	OfficeMaxSheetColumns  = 1 << 14 // XFD, the last column of spreadsheets
+)
@@ -0,0 +1,16 @@
+package constants
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	PrivacyModePlaintext = "plaintext"
+	PrivacyModeHmac      = "hmac"
+)
+
+var PrivacyModes = []string{PrivacyModePlaintext, PrivacyModeHmac}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrivacyKeyIdLabel is hashed under the HMAC key to tell keys apart without storing them.
+const PrivacyKeyIdLabel = "preprocessor/privacy-key-id"
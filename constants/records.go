@@ -0,0 +1,43 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RecordModeAuto      = "auto"      // sniffed from the start of each part
+	RecordModeLine      = "line"      // every line on its own
+	RecordModeBlank     = "blank"     // records end at blank lines
+	RecordModeSeparator = "separator" // records end at "=====" like lines
+	RecordModeStart     = "start"     // records start at lines matching --record-start
+)
+
+var RecordModes = []string{RecordModeAuto, RecordModeLine, RecordModeBlank, RecordModeSeparator, RecordModeStart}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RecordMaxLines = 256 // a record is cut after that many lines, whatever its delimiter
+	// RecordSniffRatio is the share of sampled non-blank lines that must be "key: value" fields.
+	RecordSniffRatio = 0.6
+	// RecordSniffMinimum is the number of records the sample must hold.
+	RecordSniffMinimum = 2
+)
+
+// RecordFieldPattern matches "URL: https://..." or "Password = ..." lines, LDIF "key:: base64" and vCard
+// "item1.EMAIL;TYPE=work:..." included.
+var RecordFieldPattern = regexp.MustCompile(`^\s*(?:[\w-]+\.)?([A-Za-z][\w .-]{0,39}?)(?:;[^:]*)?\s*(::?|=)\s?(.*?)\s*$`)
+
+var RecordSeparatorPattern = regexp.MustCompile(`^\s*(?:={3,}|-{3,}|\*{3,}|#{3,}|_{3,}|~{3,})\s*$`)
+
+// RecordStartPatterns are recognized by the auto mode, blank lines between such records are then optional.
+var RecordStartPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^BEGIN:VCARD\s*$`),
+	regexp.MustCompile(`(?i)^dn::?\s`),
+}
+
+// RecordMarkerPattern lines only frame records and carry no field.
+var RecordMarkerPattern = regexp.MustCompile(`(?i)^\s*(?:BEGIN|END):VCARD\s*$`)
@@ -0,0 +1,35 @@
+package constants
+
+import "regexp"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type SecretRule struct {
+	Id         string
+	Pattern    *regexp.Regexp
+	Group      int     // capture group holding the secret, 0 for the whole match
+	MinEntropy float64 // minimum Shannon entropy (bits per char) of the secret, 0 to disable
+}
+
+var SecretRules = []SecretRule{
+	{Id: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	{Id: "aws-secret-access-key", Pattern: regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|private).{0,20}?['"]?\s*[:=]\s*['"]?([A-Za-z0-9/+=]{40})\b`), Group: 1, MinEntropy: 4},
+	{Id: "github-token", Pattern: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,255}\b`)},
+	{Id: "github-fine-grained-token", Pattern: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{82}\b`)},
+	{Id: "gitlab-token", Pattern: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20}\b`)},
+	{Id: "slack-token", Pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,72}\b`)},
+	{Id: "slack-webhook", Pattern: regexp.MustCompile(`https://hooks\.slack\.com/(?:services|workflows)/[A-Za-z0-9+/]{43,56}`)},
+	{Id: "google-api-key", Pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{Id: "stripe-key", Pattern: regexp.MustCompile(`\b(?:sk|rk)_(?:live|test)_[0-9a-zA-Z]{24,99}\b`)},
+	{Id: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{Id: "generic-api-key", Pattern: regexp.MustCompile(`(?i)(?:api[_-]?key|api[_-]?secret|access[_-]?token|auth[_-]?token|client[_-]?secret)['"]?\s*[:=]\s*['"]?([A-Za-z0-9_\-./+=]{20,})`), Group: 1, MinEntropy: 3.5},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var PrivateKeyBeginPattern = regexp.MustCompile(`-----BEGIN ((?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?)-----`)
+
+var PrivateKeyEndPattern = regexp.MustCompile(`-----END (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`)
+
+const PrivateKeyMaxLines = 1 << 10
@@ -5,3 +5,6 @@ package constants
 
 const ChunkSize = 1 << 22 // 4MiB | 4.2MB
 const SolrBatchSize = 1 << 8
+const ExtractReadBufferSize = 1 << 20 // 1MiB, must stay >= CsvSniffSize
+const ExtractPendingPerThread = 4
+const BinaryLineControlRatio = 0.1 // share of control bytes above which a line looks binary
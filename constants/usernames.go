@@ -0,0 +1,112 @@
+package constants
+
+import (
+	"regexp"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// UsernameLinePattern matches "login: john" like lines, "login: john@example.com" ones being credential logins, see
+// LoginLinePattern.
+var UsernameLinePattern = regexp.MustCompile(
+	`(?i)^\s*(login|user(?:_?name)?|nick(?:_?name)?|handle|pseudo)\s*[:=]\s*([^\s@:;|,]{2,64})\s*$`,
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	HandleMentionMinLength = 3
+	HandleMentionMaxLength = 30
+	HandleMentionSource    = "mention"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ProfileUrlRule struct {
+	Platform string
+	Host     string         // looked up before the pattern is run
+	Pattern  *regexp.Regexp // the first group being the username
+	Reserved []string       // paths of the platform that are no username, lowercased
+}
+
+// ProfileUrlRules find the usernames of profile URLs, e.g. "github.com/john" or "t.me/john", Discord invites being
+// kept as the handle of their server.
+var ProfileUrlRules = []ProfileUrlRule{
+	{
+		Platform: "github",
+		Host:     "github.com/",
+		Pattern:  regexp.MustCompile(`(?i)\bgithub\.com/([a-z0-9][a-z0-9-]{0,38})\b`),
+		Reserved: []string{
+			"about", "apps", "collections", "contact", "enterprise", "explore", "features", "issues", "join", "login",
+			"marketplace", "new", "notifications", "orgs", "pricing", "pulls", "search", "security", "settings",
+			"site", "sponsors", "topics", "trending",
+		},
+	},
+	{
+		Platform: "telegram",
+		Host:     ".me/",
+		Pattern:  regexp.MustCompile(`(?i)(?:^|[^\w.])(?:t|telegram)\.me/([a-z][a-z0-9_]{4,31})\b`),
+		Reserved: []string{"addlist", "addstickers", "iv", "joinchat", "login", "proxy", "setlanguage", "share", "socks"},
+	},
+	{
+		Platform: "discord",
+		Host:     "discord",
+		Pattern:  regexp.MustCompile(`(?i)\bdiscord(?:\.gg|(?:app)?\.com/invite)/([a-z0-9-]{2,32})\b`),
+	},
+	{
+		Platform: "discord",
+		Host:     "discord",
+		Pattern:  regexp.MustCompile(`(?i)\bdiscord(?:app)?\.com/users/(\d{17,20})\b`),
+	},
+	{
+		Platform: "twitter",
+		Host:     ".com/",
+		Pattern:  regexp.MustCompile(`(?i)\b(?:twitter|x)\.com/([a-z0-9_]{1,15})\b`),
+		Reserved: []string{
+			"explore", "hashtag", "home", "i", "intent", "login", "messages", "notifications", "privacy", "search",
+			"settings", "share", "signup", "tos",
+		},
+	},
+	{
+		Platform: "instagram",
+		Host:     "instagram.com/",
+		Pattern:  regexp.MustCompile(`(?i)\binstagram\.com/([a-z0-9_](?:[a-z0-9_.]{0,28}[a-z0-9_])?)`),
+		Reserved: []string{"accounts", "direct", "explore", "p", "reel", "reels", "stories", "tv"},
+	},
+	{
+		Platform: "facebook",
+		Host:     ".com/",
+		Pattern:  regexp.MustCompile(`(?i)\b(?:facebook|fb)\.com/([a-z0-9.]{5,50})\b`),
+		Reserved: []string{
+			"dialog", "events", "groups", "help", "login", "marketplace", "pages", "permalink.php", "photo.php",
+			"plugins", "policies", "profile.php", "share", "sharer", "story.php", "watch",
+		},
+	},
+	{
+		Platform: "reddit",
+		Host:     "reddit.com/",
+		Pattern:  regexp.MustCompile(`(?i)\breddit\.com/(?:u|user)/([a-z0-9_-]{3,20})\b`),
+	},
+	{
+		Platform: "tiktok",
+		Host:     "tiktok.com/",
+		Pattern:  regexp.MustCompile(`(?i)\btiktok\.com/@([a-z0-9_.]{2,24})\b`),
+	},
+	{
+		Platform: "youtube",
+		Host:     "youtube.com/",
+		Pattern:  regexp.MustCompile(`(?i)\byoutube\.com/(?:@|c/|user/)([a-z0-9_.-]{3,30})\b`),
+	},
+	{
+		Platform: "steam",
+		Host:     "steamcommunity.com/",
+		Pattern:  regexp.MustCompile(`(?i)\bsteamcommunity\.com/id/([a-z0-9_-]{2,32})\b`),
+	},
+	{
+		Platform: "twitch",
+		Host:     "twitch.tv/",
+		Pattern:  regexp.MustCompile(`(?i)\btwitch\.tv/([a-z0-9_]{4,25})\b`),
+		Reserved: []string{"directory", "downloads", "jobs", "login", "p", "settings", "videos"},
+	},
+}
@@ -10,6 +10,7 @@ require (
 	github.com/segmentio/fasthash v1.0.3
 	github.com/urfave/cli/v3 v3.0.0-beta1
 	golang.org/x/term v0.17.0
+	golang.org/x/text v0.21.0
 	google.golang.org/protobuf v1.36.5
 )
 
@@ -19,5 +20,4 @@ require (
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
 )
@@ -3,6 +3,7 @@ package archive
 import (
 	"archive/tar"
 	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
 	"github.com/Rom1-J/preprocessor/logger"
 	"github.com/klauspost/compress/zstd"
 	"io"
@@ -13,6 +14,66 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// framedWriter ends the current zstd frame every frameSize bytes of input, see IndexZstdArchive.
+type framedWriter struct {
+	output    io.Writer
+	encoder   *zstd.Encoder
+	frameSize int
+	written   int
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func newFramedWriter(output io.Writer, frameSize int) (*framedWriter, error) {
+	encoder, err := zstd.NewWriter(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &framedWriter{output: output, encoder: encoder, frameSize: frameSize}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (w *framedWriter) Write(p []byte) (int, error) {
+	total := 0
+
+	for len(p) > 0 {
+		chunk := p
+		if room := w.frameSize - w.written; len(chunk) > room {
+			chunk = chunk[:room]
+		}
+
+		n, err := w.encoder.Write(chunk)
+		total += n
+		w.written += n
+		if err != nil {
+			return total, err
+		}
+		p = p[n:]
+
+		if w.written == w.frameSize {
+			if err := w.encoder.Close(); err != nil {
+				return total, err
+			}
+			w.encoder.Reset(w.output)
+			w.written = 0
+		}
+	}
+
+	return total, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (w *framedWriter) Close() error {
+	return w.encoder.Close()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func CompressZstdArchive(inputDirectoryPath string) (string, error) {
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
@@ -35,13 +96,13 @@ func CompressZstdArchive(inputDirectoryPath string) (string, error) {
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 	//
-	// Create archive writer
+	// Create archive writer, framed so entries can be read back through a frame index
 	//
-	zstdWriter, err := zstd.NewWriter(outFile)
+	zstdWriter, err := newFramedWriter(outFile, constants.ArchiveFrameSize)
 	if err != nil {
 		return "", fmt.Errorf("failed to create archive writer: %w", err)
 	}
-	defer func(zstdWriter *zstd.Encoder) {
+	defer func(zstdWriter *framedWriter) {
 		err := zstdWriter.Close()
 		if err != nil {
 			var msg = fmt.Sprintf("Failed to close archive writer: %v", err)
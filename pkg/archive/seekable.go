@@ -0,0 +1,351 @@
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/logger"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"os"
+	"sort"
+)
+
+// A zstd stream is a sequence of independent frames. Indexing where each frame starts in the compressed file and in
+// the decompressed tar gives random access to a tar entry: decoding starts at the frame holding the entry instead of
+// at the beginning of the archive.
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	zstdFrameMagic         = 0xFD2FB528
+	zstdSkippableMagicMask = 0xFFFFFFF0
+	zstdSkippableMagic     = 0x184D2A50
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ZstdFrame struct {
+	CompressedOffset   uint64
+	CompressedSize     uint64
+	DecompressedOffset uint64
+	DecompressedSize   uint64
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type ZstdEntry struct {
+	Header *tar.Header
+	Offset uint64 // offset of the entry data in the decompressed tar
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// frameReader decodes consecutive frames one at a time. When indexing, it fills the decompressed offset and size of
+// every frame it goes through.
+type frameReader struct {
+	file     *os.File
+	frames   []ZstdFrame
+	current  int
+	decoder  *zstd.Decoder
+	active   bool
+	indexing bool
+	position uint64
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func skipBytes(reader *bufio.Reader, n uint64) error {
+	_, err := reader.Discard(int(n))
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ScanZstdFrames walks frame and block headers (RFC 8878) without decoding, skippable frames are left out.
+func ScanZstdFrames(file *os.File) ([]ZstdFrame, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReaderSize(file, 1<<16)
+
+	var (
+		frames []ZstdFrame
+		offset uint64
+		header [4]byte
+	)
+
+	for {
+		if _, err := io.ReadFull(reader, header[:]); err == io.EOF {
+			return frames, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("truncated frame at %d: %w", offset, err)
+		}
+
+		start := offset
+		size := uint64(4)
+		magic := binary.LittleEndian.Uint32(header[:])
+
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Skippable frames (seek tables, metadata)
+		//
+		if magic&zstdSkippableMagicMask == zstdSkippableMagic {
+			if _, err := io.ReadFull(reader, header[:]); err != nil {
+				return nil, fmt.Errorf("truncated skippable frame at %d: %w", start, err)
+			}
+
+			length := uint64(binary.LittleEndian.Uint32(header[:]))
+			if err := skipBytes(reader, length); err != nil {
+				return nil, fmt.Errorf("truncated skippable frame at %d: %w", start, err)
+			}
+
+			offset += 8 + length
+			continue
+		}
+
+		if magic != zstdFrameMagic {
+			return nil, fmt.Errorf("invalid zstd magic at %d: %#x", start, magic)
+		}
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Frame header
+		//
+		descriptor, err := reader.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("truncated frame header at %d: %w", start, err)
+		}
+		size++
+
+		singleSegment := descriptor&0x20 != 0
+		hasChecksum := descriptor&0x04 != 0
+		headerSize := []uint64{0, 1, 2, 4}[descriptor&0x03]
+
+		switch descriptor >> 6 {
+		case 0:
+			if singleSegment {
+				headerSize++
+			}
+		case 1:
+			headerSize += 2
+		case 2:
+			headerSize += 4
+		case 3:
+			headerSize += 8
+		}
+		if !singleSegment {
+			headerSize++ // window descriptor
+		}
+
+		if err := skipBytes(reader, headerSize); err != nil {
+			return nil, fmt.Errorf("truncated frame header at %d: %w", start, err)
+		}
+		size += headerSize
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+		// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+		//
+		// Blocks, then optional checksum
+		//
+		for last := false; !last; {
+			var block [3]byte
+			if _, err := io.ReadFull(reader, block[:]); err != nil {
+				return nil, fmt.Errorf("truncated block header in frame at %d: %w", start, err)
+			}
+
+			blockHeader := uint64(block[0]) | uint64(block[1])<<8 | uint64(block[2])<<16
+			last = blockHeader&1 != 0
+			blockSize := blockHeader >> 3
+
+			switch (blockHeader >> 1) & 3 {
+			case 1: // RLE, a single byte repeated blockSize times
+				blockSize = 1
+			case 3:
+				return nil, fmt.Errorf("reserved block type in frame at %d", start)
+			}
+
+			if err := skipBytes(reader, blockSize); err != nil {
+				return nil, fmt.Errorf("truncated block in frame at %d: %w", start, err)
+			}
+			size += 3 + blockSize
+		}
+
+		if hasChecksum {
+			if err := skipBytes(reader, 4); err != nil {
+				return nil, fmt.Errorf("truncated checksum in frame at %d: %w", start, err)
+			}
+			size += 4
+		}
+		// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+		frames = append(frames, ZstdFrame{CompressedOffset: start, CompressedSize: size})
+		offset += size
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func newFrameReader(file *os.File, frames []ZstdFrame, current int) (*frameReader, error) {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+
+	return &frameReader{file: file, frames: frames, current: current, decoder: decoder}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *frameReader) Read(p []byte) (int, error) {
+	for r.current < len(r.frames) {
+		frame := &r.frames[r.current]
+
+		if !r.active {
+			section := io.NewSectionReader(r.file, int64(frame.CompressedOffset), int64(frame.CompressedSize))
+			if err := r.decoder.Reset(section); err != nil {
+				return 0, err
+			}
+			r.active = true
+
+			if r.indexing {
+				frame.DecompressedOffset = r.position
+			}
+		}
+
+		n, err := r.decoder.Read(p)
+		r.position += uint64(n)
+
+		if err == io.EOF {
+			if r.indexing {
+				frame.DecompressedSize = r.position - frame.DecompressedOffset
+			}
+
+			r.current++
+			r.active = false
+
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+
+		return n, err
+	}
+
+	return 0, io.EOF
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *frameReader) Close() error {
+	r.decoder.Close()
+	return r.file.Close()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IndexZstdArchive reads the archive once, calling visit with the content of every regular tar entry, and returns the
+// frame index with decompressed offsets filled.
+func IndexZstdArchive(archivePath string, visit func(entry ZstdEntry, content io.Reader) error) ([]ZstdFrame, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	frames, err := ScanZstdFrames(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to scan zstd frames: %w", err)
+	}
+
+	reader, err := newFrameReader(file, frames, 0)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	defer func(reader *frameReader) {
+		if err := reader.Close(); err != nil {
+			logger.Logger.Error().Msgf("Failed to close archive file: %v", err)
+		}
+	}(reader)
+
+	reader.indexing = true
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Tar entries, archive/tar reads headers without reading ahead so the position is at the entry data after Next
+	//
+	tarReader := tar.NewReader(reader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := visit(ZstdEntry{Header: header, Offset: reader.position}, tarReader); err != nil {
+			return nil, err
+		}
+	}
+
+	// trailing padding and frames after the end-of-archive marker
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return nil, fmt.Errorf("failed to read archive end: %w", err)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return frames, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OpenZstdEntry returns the size bytes found at offset in the decompressed tar, decoding from the frame holding them.
+func OpenZstdEntry(archivePath string, frames []ZstdFrame, offset uint64, size uint64) (io.ReadCloser, error) {
+	index := sort.Search(len(frames), func(i int) bool {
+		return frames[i].DecompressedOffset+frames[i].DecompressedSize > offset
+	})
+	if index == len(frames) {
+		if size != 0 {
+			return nil, fmt.Errorf("offset %d is past the end of %s", offset, archivePath)
+		}
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	reader, err := newFrameReader(file, frames, index)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	reader.position = frames[index].DecompressedOffset
+
+	if _, err := io.CopyN(io.Discard, reader, int64(offset-frames[index].DecompressedOffset)); err != nil {
+		_ = reader.Close()
+		return nil, fmt.Errorf("failed to seek to %d in %s: %w", offset, archivePath, err)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(reader, int64(size)), reader}, nil
+}
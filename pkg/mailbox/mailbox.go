@@ -0,0 +1,273 @@
+package mailbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"golang.org/x/text/encoding/htmlindex"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Header is either a mail.Header or the textproto.MIMEHeader of a multipart part.
+type Header interface {
+	Get(key string) string
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Part is a leaf of a MIME message, its body being transfer-decoded.
+type Part struct {
+	Index     int    // 1-based, in depth-first order
+	MediaType string // e.g. "text/plain", lowercased
+	Charset   string // of text parts, empty when unspecified
+	FileName  string // of attachments, empty for inline bodies
+	Body      io.Reader
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var fileNameRegex = regexp.MustCompile(`[^\w.-]+`)
+
+var WordDecoder = &mime.WordDecoder{CharsetReader: CharsetReader}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CharsetReader converts from the given charset to UTF-8, unknown charsets being read as is.
+func CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return input, nil
+	}
+
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Text returns the body of a text part converted to UTF-8.
+func (p Part) Text() io.Reader {
+	if p.Charset == "" {
+		return p.Body
+	}
+
+	reader, _ := CharsetReader(p.Charset, p.Body)
+
+	return reader
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsText tells whether the part is worth scanning as text, attachments of other types being left to prepare.
+func (p Part) IsText() bool {
+	return strings.HasPrefix(p.MediaType, "text/") || p.MediaType == "application/json" || p.MediaType == "application/xml"
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SafeFileName is the name prepare writes the part under, e.g. "part-01.txt" or "part-02-invoice.pdf".
+func (p Part) SafeFileName() string {
+	extension := constants.MimeExtensions[p.MediaType]
+
+	name := fileNameRegex.ReplaceAllString(filepath.Base(p.FileName), "_")
+	name = strings.Trim(name, "._")
+
+	if name == "" {
+		if extension == "" {
+			extension = ".bin"
+		}
+
+		return fmt.Sprintf("part-%02d%s", p.Index, extension)
+	}
+
+	if filepath.Ext(name) == "" {
+		name += extension
+	}
+
+	return fmt.Sprintf("part-%02d-%s", p.Index, name)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SplitMbox calls visit with each message of an mbox, ">From " escapes being undone.
+func SplitMbox(reader io.Reader, visit func(message []byte) error) error {
+	var (
+		buffered = bufio.NewReader(reader)
+		message  bytes.Buffer
+		blank    = true
+		started  bool
+	)
+
+	flush := func() error {
+		if !started {
+			return nil
+		}
+
+		err := visit(message.Bytes())
+		message.Reset()
+
+		return err
+	}
+
+	for {
+		line, err := buffered.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		switch {
+		case len(line) == 0:
+
+		case blank && bytes.HasPrefix(line, []byte(constants.MboxFromPrefix)):
+			if err := flush(); err != nil {
+				return err
+			}
+			started = true
+
+		default:
+			// mboxrd escapes every ">*From " line, mboxo only "From " ones, both are undone the same way
+			if trimmed := bytes.TrimLeft(line, ">"); len(trimmed) < len(line) &&
+				bytes.HasPrefix(trimmed, []byte(constants.MboxFromPrefix)) {
+				line = line[1:]
+			}
+
+			// a file holding a single message without "From " line
+			started = true
+			message.Write(line)
+		}
+
+		blank = len(bytes.TrimSpace(line)) == 0
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// WalkParts calls visit with every leaf part of a message body, multiparts being walked depth first. Nested
+// message/rfc822 parts are leaves as well.
+func WalkParts(header Header, body io.Reader, visit func(Part) error) error {
+	index := 0
+
+	return walkParts(header, body, &index, visit)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func walkParts(header Header, body io.Reader, index *int, visit func(Part) error) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		reader := multipart.NewReader(body, params["boundary"])
+
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := walkParts(part.Header, part, index, visit); err != nil {
+				return err
+			}
+		}
+	}
+
+	*index++
+
+	part := Part{
+		Index:     *index,
+		MediaType: mediaType,
+		FileName:  params["name"],
+		Body:      decodeTransfer(header.Get("Content-Transfer-Encoding"), body),
+	}
+
+	if strings.HasPrefix(mediaType, "text/") {
+		part.Charset = params["charset"]
+	}
+
+	if _, dispositionParams, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		if name := dispositionParams["filename"]; name != "" {
+			part.FileName = name
+		}
+	}
+
+	if decoded, err := WordDecoder.DecodeHeader(part.FileName); err == nil {
+		part.FileName = decoded
+	}
+
+	return visit(part)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		// line breaks are skipped by the decoder
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+
+	return body
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReadHeaderBlock returns the raw header lines of a message, the blank line ending them included, and leaves the
+// reader at the body.
+func ReadHeaderBlock(reader *bufio.Reader) ([]byte, error) {
+	var block bytes.Buffer
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		block.Write(line)
+
+		if err == io.EOF || (err == nil && len(bytes.TrimSpace(line)) == 0) {
+			return block.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Addresses parses an address header, encoded display names included, falling back to nothing when malformed.
+func Addresses(header mail.Header, key string) []*mail.Address {
+	parser := mail.AddressParser{WordDecoder: WordDecoder}
+
+	addresses, err := parser.ParseList(header.Get(key))
+	if err != nil {
+		return nil
+	}
+
+	return addresses
+}
@@ -0,0 +1,231 @@
+package mmdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBoolean
+	typeFloat
+)
+
+// maxDepth bounds nested maps, arrays and pointers, against crafted databases.
+const maxDepth = 64
+
+// maxSizeHint bounds the capacity preallocated for maps and arrays, whose sizes come unchecked from the database.
+const maxSizeHint = 1 << 10
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// decoder reads the data section of a database, pointers being offsets into it. Maps decode to
+// map[string]interface{}, arrays to []interface{}, unsigned integers to uint64 (uint128 to their big-endian bytes),
+// and the other types to their Go counterpart.
+type decoder struct {
+	data  []byte
+	depth int
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// decode returns the value at offset and the offset following it.
+func (d *decoder) decode(offset uint) (interface{}, uint, error) {
+	kind, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if kind == typePointer || kind == typeMap || kind == typeArray {
+		if d.depth++; d.depth > maxDepth {
+			return nil, 0, fmt.Errorf("data nested beyond %d levels", maxDepth)
+		}
+		defer func() { d.depth-- }()
+	}
+
+	if kind == typePointer {
+		// the value pointed at, decoding going on after the pointer itself
+		value, _, err := d.decode(size)
+		return value, offset, err
+	}
+
+	switch kind {
+	case typeMap:
+		value := make(map[string]interface{}, min(size, maxSizeHint))
+		for i := uint(0); i < size; i++ {
+			var key, item interface{}
+			if key, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			if item, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+
+			name, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("map key of type %T at %d", key, offset)
+			}
+			value[name] = item
+		}
+		return value, offset, nil
+
+	case typeArray:
+		value := make([]interface{}, 0, min(size, maxSizeHint))
+		for i := uint(0); i < size; i++ {
+			var item interface{}
+			if item, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			value = append(value, item)
+		}
+		return value, offset, nil
+
+	case typeBoolean:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.data)) {
+		return nil, 0, fmt.Errorf("value of %d bytes at %d beyond the data section", size, offset)
+	}
+	raw := d.data[offset : offset+size]
+	offset += size
+
+	switch kind {
+	case typeString:
+		return string(raw), offset, nil
+
+	case typeBytes, typeUint128:
+		return append([]byte(nil), raw...), offset, nil
+
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("double of %d bytes", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), offset, nil
+
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("float of %d bytes", size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(raw)), offset, nil
+
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("unsigned integer of %d bytes", size)
+		}
+		var value uint64
+		for _, b := range raw {
+			value = value<<8 | uint64(b)
+		}
+		return value, offset, nil
+
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("signed integer of %d bytes", size)
+		}
+		var value uint32
+		for _, b := range raw {
+			value = value<<8 | uint32(b)
+		}
+		return int32(value), offset, nil
+	}
+
+	return nil, 0, fmt.Errorf("unsupported data type %d at %d", kind, offset)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// control reads the control byte at offset, extended type and size bytes included, returning the type, the size
+// (the target offset for pointers) and the offset of the payload.
+func (d *decoder) control(offset uint) (int, uint, uint, error) {
+	next := func() (uint, error) {
+		if offset >= uint(len(d.data)) {
+			return 0, fmt.Errorf("truncated data at %d", offset)
+		}
+		offset++
+		return uint(d.data[offset-1]), nil
+	}
+
+	ctrl, err := next()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	kind := int(ctrl >> 5)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Pointers: 1 to 4 extra bytes, 3 bits of the control byte going first but for the 4 bytes ones
+	//
+	if kind == typePointer {
+		var (
+			length  = ctrl>>3&0x3 + 1
+			pointer uint
+		)
+		if length < 4 {
+			pointer = ctrl & 0x7
+		}
+
+		for i := uint(0); i < length; i++ {
+			b, err := next()
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			pointer = pointer<<8 | b
+		}
+
+		pointer += [...]uint{0, 2048, 526336, 0}[length-1]
+
+		return kind, pointer, offset, nil
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	if kind == typeExtended {
+		extended, err := next()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		kind = int(extended) + 7
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Sizes: up to 28 in the control byte, then 1 to 3 extra bytes
+	//
+	size := ctrl & 0x1f
+	if size >= 29 {
+		length := size - 28
+
+		var extra uint
+		for i := uint(0); i < length; i++ {
+			b, err := next()
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			extra = extra<<8 | b
+		}
+
+		size = [...]uint{29, 285, 65821}[length-1] + extra
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return kind, size, offset, nil
+}
@@ -0,0 +1,183 @@
+package mmdb
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"net/netip"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Reader looks IPs up in a MaxMind DB file (GeoLite2 Country/ASN and compatible databases), read in memory once and
+// then safe for concurrent use.
+type Reader struct {
+	Path         string
+	DatabaseType string
+	BuildEpoch   uint64
+
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	dataStart  uint // data section offset, right after the search tree and its 16 bytes separator
+	ipv4Start  uint // node IPv4 lookups start from in IPv6 trees, that of ::/96
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func Open(path string) (*Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	start := bytes.LastIndex(data, []byte(constants.MmdbMetadataMarker))
+	if start < 0 {
+		return nil, fmt.Errorf("%s is no MaxMind DB: metadata marker not found", path)
+	}
+	start += len(constants.MmdbMetadataMarker)
+
+	decoded, _, err := (&decoder{data: data[start:]}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata in %s: %v", path, err)
+	}
+
+	metadata, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid metadata in %s: not a map", path)
+	}
+
+	reader := &Reader{Path: path, data: data}
+	reader.DatabaseType, _ = metadata["database_type"].(string)
+	reader.BuildEpoch = AsUint(metadata["build_epoch"])
+	reader.nodeCount = uint(AsUint(metadata["node_count"]))
+	reader.recordSize = uint(AsUint(metadata["record_size"]))
+	reader.ipVersion = uint(AsUint(metadata["ip_version"]))
+
+	switch reader.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("unsupported record size in %s: %d", path, reader.recordSize)
+	}
+
+	reader.dataStart = reader.nodeCount*reader.recordSize/4 + constants.MmdbDataSectionSeparator
+	if reader.dataStart > uint(len(data)) {
+		return nil, fmt.Errorf("invalid search tree in %s: %d nodes", path, reader.nodeCount)
+	}
+
+	if reader.ipVersion == 6 {
+		for i := 0; i < 96 && reader.ipv4Start < reader.nodeCount; i++ {
+			reader.ipv4Start = reader.record(reader.ipv4Start, 0)
+		}
+	}
+
+	return reader, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Lookup returns the record of the network the IP belongs to, nil when the database has none.
+func (r *Reader) Lookup(ip netip.Addr) (map[string]interface{}, error) {
+	ip = ip.Unmap()
+
+	var (
+		address = ip.AsSlice()
+		node    uint
+	)
+
+	if ip.Is4() && r.ipVersion == 6 {
+		node = r.ipv4Start
+	} else if ip.Is6() && r.ipVersion == 4 {
+		return nil, nil
+	}
+
+	for i := 0; i < len(address)*8 && node < r.nodeCount; i++ {
+		bit := uint(address[i/8]>>(7-i%8)) & 1
+		node = r.record(node, bit)
+	}
+
+	if node <= r.nodeCount {
+		// no network, or a tree deeper than the address
+		return nil, nil
+	}
+
+	offset := node - r.nodeCount - constants.MmdbDataSectionSeparator
+	if r.dataStart+offset >= uint(len(r.data)) {
+		return nil, fmt.Errorf("invalid record pointer in %s: %d", r.Path, node)
+	}
+
+	decoded, _, err := (&decoder{data: r.data[r.dataStart:]}).decode(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	record, _ := decoded.(map[string]interface{})
+
+	return record, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// record reads the left (bit 0) or right (bit 1) record of a search tree node.
+func (r *Reader) record(node uint, bit uint) uint {
+	offset := node * r.recordSize / 4
+	if offset+r.recordSize/4 > uint(len(r.data)) {
+		return r.nodeCount
+	}
+	b := r.data[offset:]
+
+	switch r.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xf0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+
+	default:
+		b = b[bit*4:]
+		return uint(b[0])<<24 | uint(b[1])<<16 | uint(b[2])<<8 | uint(b[3])
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Path returns the value at the keys of a record, nil when missing, e.g. Path(record, "country", "iso_code").
+func Path(record map[string]interface{}, keys ...string) interface{} {
+	var value interface{} = record
+
+	for _, key := range keys {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = node[key]
+	}
+
+	return value
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AsUint reads the unsigned integers of a record whatever their size, 0 for other types.
+func AsUint(value interface{}) uint64 {
+	switch typed := value.(type) {
+	case uint64:
+		return typed
+	case int32:
+		if typed > 0 {
+			return uint64(typed)
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,207 @@
+package noise
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Rule drops the domains or emails it matches, in its buckets only when it has any.
+type Rule struct {
+	Name    string // reported with the count of entities the rule removed, e.g. "domain suffix w3.org"
+	Type    string
+	Match   string
+	Value   string
+	Pattern *regexp.Regexp
+	Buckets []infoproto.Bucket
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Filter holds the rules of a bucket, exact and suffix values being looked up by entity type and value.
+type Filter struct {
+	exact    map[string]string
+	suffix   map[string]string
+	patterns []Rule
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Load reads a --noise-filter list, e.g.
+//
+//	[{"type": "domain", "suffix": ["w3.org", "googleapis.com"], "exact": ["example.com"]},
+//	 {"type": "email", "regex": ["^no-?reply@"], "buckets": ["leaks.logs"]}]
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		Type    string   `json:"type"`
+		Exact   []string `json:"exact"`
+		Suffix  []string `json:"suffix"`
+		Regex   []string `json:"regex"`
+		Buckets []string `json:"buckets"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid noise filter %s: %v", path, err)
+	}
+
+	var rules []Rule
+
+	for _, entry := range entries {
+		entityType := strings.ToLower(entry.Type)
+		if entityType != "" && !slices.Contains(constants.NoiseEntityTypes, entityType) {
+			return nil, fmt.Errorf(
+				"invalid noise filter %s: expected type %s, got: %s",
+				path, strings.Join(constants.NoiseEntityTypes, " or "), entry.Type,
+			)
+		}
+
+		var buckets []infoproto.Bucket
+		for _, name := range entry.Buckets {
+			bucket, ok := constants.BucketNames[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("invalid noise filter %s: unknown bucket %s", path, name)
+			}
+			buckets = append(buckets, bucket)
+		}
+
+		for _, match := range []struct {
+			kind   string
+			values []string
+		}{
+			{constants.NoiseMatchExact, entry.Exact},
+			{constants.NoiseMatchSuffix, entry.Suffix},
+			{constants.NoiseMatchRegex, entry.Regex},
+		} {
+			for _, value := range match.values {
+				rule := Rule{Type: entityType, Match: match.kind, Value: value, Buckets: buckets}
+
+				if match.kind == constants.NoiseMatchRegex {
+					if rule.Pattern, err = regexp.Compile(value); err != nil {
+						return nil, fmt.Errorf("invalid noise filter %s: pattern %q: %v", path, value, err)
+					}
+				} else {
+					rule.Value = strings.TrimPrefix(strings.ToLower(value), ".")
+				}
+
+				rule.Name = strings.TrimSpace(entityType + " " + match.kind + " " + rule.Value)
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NewFilter keeps the rules applying to the bucket.
+func NewFilter(rules []Rule, bucket infoproto.Bucket) *Filter {
+	filter := &Filter{exact: make(map[string]string), suffix: make(map[string]string)}
+
+	for _, rule := range rules {
+		if len(rule.Buckets) > 0 && !slices.Contains(rule.Buckets, bucket) {
+			continue
+		}
+
+		if rule.Match == constants.NoiseMatchRegex {
+			filter.patterns = append(filter.patterns, rule)
+			continue
+		}
+
+		types := constants.NoiseEntityTypes
+		if rule.Type != "" {
+			types = []string{rule.Type}
+		}
+
+		for _, entityType := range types {
+			key := entityType + "/" + rule.Value
+			if rule.Match == constants.NoiseMatchExact {
+				filter.exact[key] = rule.Name
+			} else {
+				filter.suffix[key] = rule.Name
+			}
+		}
+	}
+
+	return filter
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (f *Filter) Empty() bool {
+	return len(f.exact) == 0 && len(f.suffix) == 0 && len(f.patterns) == 0
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Names lists the rules of the filter, sorted.
+func (f *Filter) Names() []string {
+	var names []string
+
+	for _, name := range f.exact {
+		names = append(names, name)
+	}
+	for _, name := range f.suffix {
+		names = append(names, name)
+	}
+	for _, rule := range f.patterns {
+		names = append(names, rule.Name)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Match returns the name of the first rule matching the domain or email, exact values going first, then suffixes
+// from the longest, then patterns in file order, all of them on the lowercased value.
+func (f *Filter) Match(entityType string, value string) (string, bool) {
+	value = strings.ToLower(value)
+
+	if name, ok := f.exact[entityType+"/"+value]; ok {
+		return name, true
+	}
+
+	if len(f.suffix) > 0 {
+		domain := value
+		if entityType == constants.EntityTypeEmail {
+			domain = value[strings.LastIndexByte(value, '@')+1:]
+		}
+
+		for {
+			if name, ok := f.suffix[entityType+"/"+domain]; ok {
+				return name, true
+			}
+
+			dot := strings.IndexByte(domain, '.')
+			if dot < 0 {
+				break
+			}
+			domain = domain[dot+1:]
+		}
+	}
+
+	for _, rule := range f.patterns {
+		if (rule.Type == "" || rule.Type == entityType) && rule.Pattern.MatchString(value) {
+			return rule.Name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,138 @@
+package office
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"github.com/Rom1-J/preprocessor/constants"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	wordEntriesRegex       = regexp.MustCompile(`^word/(document|header\d*|footer\d*|footnotes|endnotes|comments)\.xml$`)
+	presentationEntryRegex = regexp.MustCompile(`^ppt/(slides/slide|notesSlides/notesSlide)\d+\.xml$`)
+	openDocumentEntryRegex = regexp.MustCompile(`^content\.xml$`)
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReadDocument writes the text of a word processing document or presentation, one paragraph per line.
+func ReadDocument(filePath string, output io.Writer) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	var (
+		writer  = bufio.NewWriter(output)
+		pattern = wordEntriesRegex
+		visit   = ooxmlText(writer)
+	)
+
+	switch {
+	case isOpenDocument(filePath):
+		pattern, visit = openDocumentEntryRegex, openDocumentText(writer)
+	case strings.ToLower(filepath.Ext(filePath)) == ".pptx":
+		pattern = presentationEntryRegex
+	}
+
+	for _, name := range entriesMatching(&archive.Reader, pattern) {
+		if err := decodeEntry(&archive.Reader, name, visit); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ooxmlText keeps the <w:t> and <a:t> runs of WordprocessingML and DrawingML, deleted runs and field codes being
+// other elements.
+func ooxmlText(writer *bufio.Writer) func(*xml.Decoder, xml.Token) error {
+	var inText, inProperties int
+
+	return func(_ *xml.Decoder, token xml.Token) error {
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "t":
+				inText++
+			case "pPr", "rPr":
+				inProperties++
+			case "tab":
+				if inProperties == 0 {
+					_ = writer.WriteByte('\t')
+				}
+			case "br", "cr":
+				_ = writer.WriteByte('\n')
+			}
+
+		case xml.EndElement:
+			switch token.Name.Local {
+			case "t":
+				inText--
+			case "pPr", "rPr":
+				inProperties--
+			case "p":
+				_ = writer.WriteByte('\n')
+			}
+
+		case xml.CharData:
+			if inText > 0 {
+				_, _ = writer.Write(token)
+			}
+		}
+
+		return nil
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// openDocumentText keeps the mixed content of <text:p> and <text:h>, spans and links included.
+func openDocumentText(writer *bufio.Writer) func(*xml.Decoder, xml.Token) error {
+	var inParagraph int
+
+	return func(_ *xml.Decoder, token xml.Token) error {
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "p", "h":
+				inParagraph++
+			case "s":
+				count := min(repeatedAttribute(token, "c"), constants.OfficeMaxRepeatedCells)
+				_, _ = writer.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				_ = writer.WriteByte('\t')
+			case "line-break":
+				_ = writer.WriteByte('\n')
+			}
+
+		case xml.EndElement:
+			if token.Name.Local == "p" || token.Name.Local == "h" {
+				inParagraph--
+				_ = writer.WriteByte('\n')
+			}
+
+		case xml.CharData:
+			if inParagraph > 0 {
+				_, _ = writer.Write(token)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package office
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"io"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var entryNumberRegex = regexp.MustCompile(`(\d+)\.xml$`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsSpreadsheet(filePath string) bool {
+	return slices.Contains(constants.OfficeSpreadsheetExtensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func IsDocument(filePath string) bool {
+	return slices.Contains(constants.OfficeDocumentExtensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// isOpenDocument tells OpenDocument files from Office Open XML ones, both being zip containers.
+func isOpenDocument(filePath string) bool {
+	return strings.HasPrefix(strings.ToLower(filepath.Ext(filePath)), ".od")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// openEntry opens a zip entry by name, refusing the ones decompressing beyond OfficeMaxEntrySize.
+func openEntry(archive *zip.Reader, name string) (io.ReadCloser, error) {
+	name = strings.TrimPrefix(path.Clean(name), "/")
+
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+
+		if file.UncompressedSize64 > constants.OfficeMaxEntrySize {
+			return nil, fmt.Errorf("entry %s too big: %d bytes", name, file.UncompressedSize64)
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(reader, constants.OfficeMaxEntrySize), reader}, nil
+	}
+
+	return nil, fmt.Errorf("entry %s not found", name)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// entriesMatching lists the entries matching pattern, "slide2.xml" coming before "slide10.xml".
+func entriesMatching(archive *zip.Reader, pattern *regexp.Regexp) []string {
+	var names []string
+
+	for _, file := range archive.File {
+		if pattern.MatchString(file.Name) {
+			names = append(names, file.Name)
+		}
+	}
+
+	number := func(name string) int {
+		match := entryNumberRegex.FindStringSubmatch(name)
+		if match == nil {
+			return 0
+		}
+
+		value, _ := strconv.Atoi(match[1])
+
+		return value
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		if a, b := entryNumberRegex.ReplaceAllString(names[i], ""), entryNumberRegex.ReplaceAllString(names[j], ""); a != b {
+			return a < b
+		}
+
+		return number(names[i]) < number(names[j])
+	})
+
+	return names
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// decodeEntry walks the XML tokens of a zip entry.
+func decodeEntry(archive *zip.Reader, name string, visit func(decoder *xml.Decoder, token xml.Token) error) error {
+	reader, err := openEntry(archive, name)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	decoder := xml.NewDecoder(reader)
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid XML in %s: %v", name, err)
+		}
+
+		if err := visit(decoder, token); err != nil {
+			return err
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func attribute(element xml.StartElement, local string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,358 @@
+package office
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/constants"
+	"path"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SheetCreator returns where the rows of the index-th sheet, 1-based, are written.
+type SheetCreator func(index int, name string) (*csv.Writer, error)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReadSpreadsheet writes every sheet of a workbook as CSV, empty rows and trailing empty cells being dropped.
+// Cells hold their stored value, dates of Office Open XML workbooks staying serial numbers.
+func ReadSpreadsheet(filePath string, create SheetCreator) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	if isOpenDocument(filePath) {
+		return readOpenDocumentSheets(&archive.Reader, create)
+	}
+
+	return readOoxmlSheets(&archive.Reader, create)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func flushSheet(writer *csv.Writer) error {
+	writer.Flush()
+
+	return writer.Error()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// columnIndex turns the letters of a cell reference into a 0-based column, e.g. "AB12" into 27.
+func columnIndex(reference string) (int, bool) {
+	index := 0
+	letters := 0
+
+	for _, char := range strings.ToUpper(reference) {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+
+		index = index*26 + int(char-'A'+1)
+		letters++
+	}
+
+	return index - 1, letters > 0
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func readOoxmlSheets(archive *zip.Reader, create SheetCreator) error {
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Sheet names and entries, in workbook order
+	//
+	targets := make(map[string]string)
+
+	if err := decodeEntry(archive, "xl/_rels/workbook.xml.rels", func(_ *xml.Decoder, token xml.Token) error {
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "Relationship" {
+			target := attribute(element, "Target")
+			if !strings.HasPrefix(target, "/") {
+				target = path.Join("xl", target)
+			}
+
+			targets[attribute(element, "Id")] = target
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var sheets [][2]string // name, entry
+
+	if err := decodeEntry(archive, "xl/workbook.xml", func(_ *xml.Decoder, token xml.Token) error {
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "sheet" {
+			if target, ok := targets[attribute(element, "id")]; ok {
+				sheets = append(sheets, [2]string{attribute(element, "name"), target})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Shared strings, rich text runs joined and phonetic hints dropped
+	//
+	var (
+		shared        []string
+		text          strings.Builder
+		inText, inRph int
+	)
+
+	// workbooks holding only numbers have none
+	_ = decodeEntry(archive, "xl/sharedStrings.xml", func(_ *xml.Decoder, token xml.Token) error {
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "si":
+				text.Reset()
+			case "t":
+				inText++
+			case "rPh":
+				inRph++
+			}
+
+		case xml.EndElement:
+			switch token.Name.Local {
+			case "si":
+				shared = append(shared, text.String())
+			case "t":
+				inText--
+			case "rPh":
+				inRph--
+			}
+
+		case xml.CharData:
+			if inText > 0 && inRph == 0 {
+				text.Write(token)
+			}
+		}
+
+		return nil
+	})
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Rows
+	//
+	for index, sheet := range sheets {
+		writer, err := create(index+1, sheet[0])
+		if err != nil {
+			return err
+		}
+
+		var (
+			row              []string
+			cellType, value  string
+			column           int
+			inValue, inCells int
+		)
+
+		if err := decodeEntry(archive, sheet[1], func(_ *xml.Decoder, token xml.Token) error {
+			switch token := token.(type) {
+			case xml.StartElement:
+				switch token.Name.Local {
+				case "row":
+					row, column = row[:0], 0
+				case "c":
+					cellType, value = attribute(token, "t"), ""
+					if position, ok := columnIndex(attribute(token, "r")); ok {
+						column = position
+					}
+					inCells++
+				case "v", "t":
+					inValue++
+				}
+
+			case xml.EndElement:
+				switch token.Name.Local {
+				case "row":
+					for len(row) > 0 && row[len(row)-1] == "" {
+						row = row[:len(row)-1]
+					}
+					if len(row) > 0 {
+						return writer.Write(row)
+					}
+				case "c":
+					inCells--
+
+					switch cellType {
+					case "s":
+						if position, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && position < len(shared) {
+							value = shared[position]
+						}
+					case "b":
+						value = map[string]string{"0": "FALSE", "1": "TRUE"}[strings.TrimSpace(value)]
+					}
+
+					if column < constants.OfficeMaxSheetColumns {
+						for len(row) < column {
+							row = append(row, "")
+						}
+						row = append(row, value)
+					}
+					column = len(row)
+				case "v", "t":
+					inValue--
+				}
+
+			case xml.CharData:
+				if inCells > 0 && inValue > 0 {
+					value += string(token)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := flushSheet(writer); err != nil {
+			return err
+		}
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func repeatedAttribute(element xml.StartElement, local string) int {
+	count, err := strconv.Atoi(attribute(element, local))
+	if err != nil || count < 1 {
+		return 1
+	}
+
+	return count
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// readOpenDocumentSheets expands repeated rows and cells, empty repeats only when followed by a value.
+func readOpenDocumentSheets(archive *zip.Reader, create SheetCreator) error {
+	var (
+		writer *csv.Writer
+		sheets int
+
+		row                   []string
+		rowRepeat, cellRepeat int
+		pendingEmpty          int
+		cell                  strings.Builder
+		inCell, inParagraph   int
+		paragraphs            int
+	)
+
+	err := decodeEntry(archive, "content.xml", func(_ *xml.Decoder, token xml.Token) error {
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "table":
+				sheets++
+
+				var err error
+				if writer, err = create(sheets, attribute(token, "name")); err != nil {
+					return err
+				}
+			case "table-row":
+				row, pendingEmpty = row[:0], 0
+				rowRepeat = repeatedAttribute(token, "number-rows-repeated")
+			case "table-cell", "covered-table-cell":
+				cell.Reset()
+				cellRepeat, paragraphs = repeatedAttribute(token, "number-columns-repeated"), 0
+				inCell++
+			case "p":
+				if inCell > 0 && paragraphs > 0 {
+					cell.WriteByte('\n')
+				}
+				paragraphs++
+				inParagraph++
+			case "s":
+				if inParagraph > 0 {
+					cell.WriteString(strings.Repeat(" ", min(repeatedAttribute(token, "c"), constants.OfficeMaxRepeatedCells)))
+				}
+			case "tab":
+				if inParagraph > 0 {
+					cell.WriteByte('\t')
+				}
+			case "line-break":
+				if inParagraph > 0 {
+					cell.WriteByte('\n')
+				}
+			}
+
+		case xml.EndElement:
+			switch token.Name.Local {
+			case "table":
+				if writer != nil {
+					if err := flushSheet(writer); err != nil {
+						return err
+					}
+				}
+				writer = nil
+			case "table-row":
+				if len(row) == 0 || writer == nil {
+					break
+				}
+				for range min(rowRepeat, constants.OfficeMaxRepeatedCells) {
+					if err := writer.Write(row); err != nil {
+						return err
+					}
+				}
+			case "table-cell", "covered-table-cell":
+				inCell--
+
+				if cell.Len() == 0 {
+					pendingEmpty += cellRepeat
+					break
+				}
+
+				for ; pendingEmpty > 0 && len(row) < constants.OfficeMaxSheetColumns; pendingEmpty-- {
+					row = append(row, "")
+				}
+				for range min(cellRepeat, constants.OfficeMaxRepeatedCells) {
+					if len(row) < constants.OfficeMaxSheetColumns {
+						row = append(row, cell.String())
+					}
+				}
+				pendingEmpty = 0
+			case "p":
+				inParagraph--
+			}
+
+		case xml.CharData:
+			if inCell > 0 && inParagraph > 0 {
+				cell.Write(token)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if sheets == 0 {
+		return fmt.Errorf("no sheet found")
+	}
+
+	return nil
+}
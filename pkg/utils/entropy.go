@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"math"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func ShannonEntropy(value string) float64 {
+	if len(value) == 0 {
+		return 0
+	}
+
+	var frequencies [256]int
+	for i := 0; i < len(value); i++ {
+		frequencies[value[i]]++
+	}
+
+	var entropy float64
+	for _, frequency := range frequencies {
+		if frequency == 0 {
+			continue
+		}
+
+		p := float64(frequency) / float64(len(value))
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
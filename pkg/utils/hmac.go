@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Rom1-J/preprocessor/constants"
+	"net"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func KeyedHash(key []byte, value []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(value)
+
+	return []byte(hex.EncodeToString(mac.Sum(nil)))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// KeyId identifies the key a dataset was hashed with, without giving it away.
+func KeyId(key []byte) string {
+	if len(key) == 0 {
+		return ""
+	}
+
+	return string(KeyedHash(key, []byte(constants.PrivacyKeyIdLabel))[:16])
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NormalizeEntity is the form entities are hashed in with --privacy-mode hmac, lookups must normalize the same way.
+func NormalizeEntity(entityType string, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch entityType {
+	case constants.EntityTypeEmail, constants.EntityTypeUsername:
+		return strings.ToLower(value)
+	case constants.EntityTypeDomain:
+		return strings.TrimSuffix(strings.ToLower(value), ".")
+	case constants.EntityTypeIp:
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	}
+
+	return value
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func EntityHash(key []byte, entityType string, value string) []byte {
+	return KeyedHash(key, []byte(NormalizeEntity(entityType, value)))
+}
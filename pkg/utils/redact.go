@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"bytes"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func Redact(value string, head int, tail int) []byte {
+	if head+tail >= len(value) {
+		return bytes.Repeat([]byte("*"), len(value))
+	}
+
+	return append(
+		append([]byte(value[:head]), bytes.Repeat([]byte("*"), len(value)-head-tail)...),
+		value[len(value)-tail:]...,
+	)
+}
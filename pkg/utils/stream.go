@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/Rom1-J/preprocessor/logger"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"os"
+	"slices"
+)
+
+// Protobuf messages are streamed as a sequence of length-delimited top-level fields (tag, varint length, bytes).
+// Appending `repeated` items this way keeps the file a valid message, e.g. a MetadataList, without holding every
+// item in memory before a single proto.Marshal.
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const streamBufferSize = 1 << 20
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type DelimitedWriter struct {
+	path      string
+	temporary string
+	file      *os.File
+	buffer    *bufio.Writer
+	scratch   []byte
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type DelimitedReader struct {
+	file    *os.File
+	buffer  *bufio.Reader
+	offset  int64
+	skipped []protowire.Number
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CreateDelimitedWriter writes to path.tmp, renamed to path on Close so readers never see a partial file.
+func CreateDelimitedWriter(path string) (*DelimitedWriter, error) {
+	temporary := path + ".tmp"
+
+	file, err := os.Create(temporary)
+	if err != nil {
+		var msg = fmt.Sprintf("Error creating stream %s: %v", temporary, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	return &DelimitedWriter{
+		path:      path,
+		temporary: temporary,
+		file:      file,
+		buffer:    bufio.NewWriterSize(file, streamBufferSize),
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (w *DelimitedWriter) Write(field protowire.Number, message proto.Message) error {
+	data, err := proto.MarshalOptions{}.MarshalAppend(w.scratch[:0], message)
+	if err != nil {
+		return err
+	}
+	w.scratch = data
+
+	return w.WriteRaw(field, data)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// WriteRaw appends an already marshaled message, e.g. one copied from a previous stream.
+func (w *DelimitedWriter) WriteRaw(field protowire.Number, data []byte) error {
+	var header []byte
+	header = protowire.AppendTag(header, field, protowire.BytesType)
+	header = protowire.AppendVarint(header, uint64(len(data)))
+
+	if _, err := w.buffer.Write(header); err != nil {
+		return err
+	}
+	_, err := w.buffer.Write(data)
+
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (w *DelimitedWriter) Close() error {
+	if err := w.buffer.Flush(); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(w.temporary, w.path)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (w *DelimitedWriter) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.temporary)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func OpenDelimitedReader(path string) (*DelimitedReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		var msg = fmt.Sprintf("Error opening stream %s: %v", path, err)
+		logger.Logger.Error().Msg(msg)
+
+		return nil, fmt.Errorf(msg)
+	}
+
+	return &DelimitedReader{file: file, buffer: bufio.NewReaderSize(file, streamBufferSize)}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Skip makes Next discard the given fields as well, without reading them in memory.
+func (r *DelimitedReader) Skip(fields ...protowire.Number) {
+	r.skipped = append(r.skipped, fields...)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Next returns the next length-delimited top-level field, skipping scalar ones, and io.EOF at the end of the stream.
+func (r *DelimitedReader) Next() (protowire.Number, []byte, error) {
+	for {
+		tag, err := r.readVarint()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		field, wireType := protowire.DecodeTag(tag)
+		if field < protowire.MinValidNumber {
+			return 0, nil, fmt.Errorf("invalid field number %d", field)
+		}
+
+		switch wireType {
+		case protowire.VarintType:
+			_, err = r.readVarint()
+		case protowire.Fixed32Type:
+			err = r.discard(4)
+		case protowire.Fixed64Type:
+			err = r.discard(8)
+		case protowire.BytesType:
+			var length uint64
+			if length, err = r.readVarint(); err != nil {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+
+			if slices.Contains(r.skipped, field) {
+				err = r.discard(int(length))
+				break
+			}
+
+			data := make([]byte, length)
+			if _, err = io.ReadFull(r.buffer, data); err != nil {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+			r.offset += int64(length)
+
+			return field, data, nil
+		default:
+			return 0, nil, fmt.Errorf("unsupported wire type %d for field %d", wireType, field)
+		}
+
+		if err != nil {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Offset is the number of bytes consumed, the last field returned by Next ends there.
+func (r *DelimitedReader) Offset() int64 {
+	return r.offset
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *DelimitedReader) Close() error {
+	return r.file.Close()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *DelimitedReader) discard(n int) error {
+	discarded, err := r.buffer.Discard(n)
+	r.offset += int64(discarded)
+
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (r *DelimitedReader) readVarint() (uint64, error) {
+	var value uint64
+
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := r.buffer.ReadByte()
+		if err != nil {
+			if shift > 0 && err == io.EOF {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		r.offset++
+
+		value |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("varint overflow")
+}
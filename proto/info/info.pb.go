@@ -76,6 +76,120 @@ func (Bucket) EnumDescriptor() ([]byte, []int) {
 	return file_proto_info_info_proto_rawDescGZIP(), []int{0}
 }
 
+type Storage int32
+
+const (
+	Storage_STORAGE_EXTRACTED Storage = 0
+	Storage_STORAGE_ARCHIVE   Storage = 1
+)
+
+// Enum value maps for Storage.
+var (
+	Storage_name = map[int32]string{
+		0: "STORAGE_EXTRACTED",
+		1: "STORAGE_ARCHIVE",
+	}
+	Storage_value = map[string]int32{
+		"STORAGE_EXTRACTED": 0,
+		"STORAGE_ARCHIVE":   1,
+	}
+)
+
+func (x Storage) Enum() *Storage {
+	p := new(Storage)
+	*p = x
+	return p
+}
+
+func (x Storage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Storage) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_info_info_proto_enumTypes[1].Descriptor()
+}
+
+func (Storage) Type() protoreflect.EnumType {
+	return &file_proto_info_info_proto_enumTypes[1]
+}
+
+func (x Storage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Storage.Descriptor instead.
+func (Storage) EnumDescriptor() ([]byte, []int) {
+	return file_proto_info_info_proto_rawDescGZIP(), []int{1}
+}
+
+type ArchiveFrame struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CompressedOffset   uint64                 `protobuf:"varint,1,opt,name=compressed_offset,json=compressedOffset,proto3" json:"compressed_offset,omitempty"`
+	CompressedSize     uint64                 `protobuf:"varint,2,opt,name=compressed_size,json=compressedSize,proto3" json:"compressed_size,omitempty"`
+	DecompressedOffset uint64                 `protobuf:"varint,3,opt,name=decompressed_offset,json=decompressedOffset,proto3" json:"decompressed_offset,omitempty"`
+	DecompressedSize   uint64                 `protobuf:"varint,4,opt,name=decompressed_size,json=decompressedSize,proto3" json:"decompressed_size,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ArchiveFrame) Reset() {
+	*x = ArchiveFrame{}
+	mi := &file_proto_info_info_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveFrame) ProtoMessage() {}
+
+func (x *ArchiveFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_info_info_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveFrame.ProtoReflect.Descriptor instead.
+func (*ArchiveFrame) Descriptor() ([]byte, []int) {
+	return file_proto_info_info_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ArchiveFrame) GetCompressedOffset() uint64 {
+	if x != nil {
+		return x.CompressedOffset
+	}
+	return 0
+}
+
+func (x *ArchiveFrame) GetCompressedSize() uint64 {
+	if x != nil {
+		return x.CompressedSize
+	}
+	return 0
+}
+
+func (x *ArchiveFrame) GetDecompressedOffset() uint64 {
+	if x != nil {
+		return x.DecompressedOffset
+	}
+	return 0
+}
+
+func (x *ArchiveFrame) GetDecompressedSize() uint64 {
+	if x != nil {
+		return x.DecompressedSize
+	}
+	return 0
+}
+
 type MetadataInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -85,13 +199,16 @@ type MetadataInfo struct {
 	Size          uint64                 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
 	Simhash       uint64                 `protobuf:"varint,6,opt,name=simhash,proto3" json:"simhash,omitempty"`
 	Children      []*MetadataInfo        `protobuf:"bytes,7,rep,name=children,proto3" json:"children,omitempty"`
+	Storage       Storage                `protobuf:"varint,8,opt,name=storage,proto3,enum=metadata.Storage" json:"storage,omitempty"`
+	Frames        []*ArchiveFrame        `protobuf:"bytes,9,rep,name=frames,proto3" json:"frames,omitempty"`
+	ArchiveOffset uint64                 `protobuf:"varint,10,opt,name=archive_offset,json=archiveOffset,proto3" json:"archive_offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MetadataInfo) Reset() {
 	*x = MetadataInfo{}
-	mi := &file_proto_info_info_proto_msgTypes[0]
+	mi := &file_proto_info_info_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -103,7 +220,7 @@ func (x *MetadataInfo) String() string {
 func (*MetadataInfo) ProtoMessage() {}
 
 func (x *MetadataInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_info_info_proto_msgTypes[0]
+	mi := &file_proto_info_info_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,7 +233,7 @@ func (x *MetadataInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetadataInfo.ProtoReflect.Descriptor instead.
 func (*MetadataInfo) Descriptor() ([]byte, []int) {
-	return file_proto_info_info_proto_rawDescGZIP(), []int{0}
+	return file_proto_info_info_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *MetadataInfo) GetId() string {
@@ -168,31 +285,76 @@ func (x *MetadataInfo) GetChildren() []*MetadataInfo {
 	return nil
 }
 
+func (x *MetadataInfo) GetStorage() Storage {
+	if x != nil {
+		return x.Storage
+	}
+	return Storage_STORAGE_EXTRACTED
+}
+
+func (x *MetadataInfo) GetFrames() []*ArchiveFrame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+func (x *MetadataInfo) GetArchiveOffset() uint64 {
+	if x != nil {
+		return x.ArchiveOffset
+	}
+	return 0
+}
+
 var File_proto_info_info_proto protoreflect.FileDescriptor
 
 var file_proto_info_info_proto_rawDesc = string([]byte{
 	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x69, 0x6e, 0x66,
 	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0xd2, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6d, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6d, 0x68, 0x61,
-	0x73, 0x68, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x07,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x63, 0x68,
-	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x2a, 0x59, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x55, 0x4d, 0x50, 0x53, 0x54, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e,
-	0x0a, 0x0a, 0x4c, 0x45, 0x41, 0x4b, 0x53, 0x5f, 0x4c, 0x4f, 0x47, 0x53, 0x10, 0x01, 0x12, 0x13,
-	0x0a, 0x0f, 0x4c, 0x45, 0x41, 0x4b, 0x53, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45,
-	0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4d, 0x42, 0x49, 0x4e, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x53, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x41, 0x53, 0x54, 0x45, 0x53, 0x10,
-	0x04, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x22, 0xc2, 0x01, 0x0a, 0x0c, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x65, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xd6, 0x02, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x69, 0x6d, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x73, 0x69, 0x6d, 0x68, 0x61, 0x73, 0x68, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64,
+	0x72, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x07, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x2a,
+	0x59, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x55, 0x4d,
+	0x50, 0x53, 0x54, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x45, 0x41, 0x4b, 0x53,
+	0x5f, 0x4c, 0x4f, 0x47, 0x53, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x45, 0x41, 0x4b, 0x53,
+	0x5f, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c,
+	0x43, 0x4f, 0x4d, 0x42, 0x49, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x03, 0x12, 0x0a,
+	0x0a, 0x06, 0x50, 0x41, 0x53, 0x54, 0x45, 0x53, 0x10, 0x04, 0x2a, 0x35, 0x0a, 0x07, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45,
+	0x5f, 0x45, 0x58, 0x54, 0x52, 0x41, 0x43, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
+	0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -207,20 +369,24 @@ func file_proto_info_info_proto_rawDescGZIP() []byte {
 	return file_proto_info_info_proto_rawDescData
 }
 
-var file_proto_info_info_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_info_info_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_info_info_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_info_info_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_proto_info_info_proto_goTypes = []any{
 	(Bucket)(0),          // 0: metadata.Bucket
-	(*MetadataInfo)(nil), // 1: metadata.MetadataInfo
+	(Storage)(0),         // 1: metadata.Storage
+	(*ArchiveFrame)(nil), // 2: metadata.ArchiveFrame
+	(*MetadataInfo)(nil), // 3: metadata.MetadataInfo
 }
 var file_proto_info_info_proto_depIdxs = []int32{
 	0, // 0: metadata.MetadataInfo.bucket:type_name -> metadata.Bucket
-	1, // 1: metadata.MetadataInfo.children:type_name -> metadata.MetadataInfo
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 1: metadata.MetadataInfo.children:type_name -> metadata.MetadataInfo
+	1, // 2: metadata.MetadataInfo.storage:type_name -> metadata.Storage
+	2, // 3: metadata.MetadataInfo.frames:type_name -> metadata.ArchiveFrame
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_info_info_proto_init() }
@@ -233,8 +399,8 @@ func file_proto_info_info_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_info_info_proto_rawDesc), len(file_proto_info_info_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      2,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
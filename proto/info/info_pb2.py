@@ -24,15 +24,19 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15proto/info/info.proto\x12\x08metadata\"\xa1\x01\n\x0cMetadataInfo\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04\x64\x61te\x18\x02 \x01(\t\x12 \n\x06\x62ucket\x18\x03 \x01(\x0e\x32\x10.metadata.Bucket\x12\x0c\n\x04path\x18\x04 \x01(\x0c\x12\x0c\n\x04size\x18\x05 \x01(\x04\x12\x0f\n\x07simhash\x18\x06 \x01(\x04\x12(\n\x08\x63hildren\x18\x07 \x03(\x0b\x32\x16.metadata.MetadataInfo*Y\n\x06\x42ucket\x12\x0c\n\x08\x44UMPSTER\x10\x00\x12\x0e\n\nLEAKS_LOGS\x10\x01\x12\x13\n\x0fLEAKS_DATABASES\x10\x02\x12\x10\n\x0c\x43OMBINATIONS\x10\x03\x12\n\n\x06PASTES\x10\x04\x62\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15proto/info/info.proto\x12\x08metadata\"z\n\x0c\x41rchiveFrame\x12\x19\n\x11\x63ompressed_offset\x18\x01 \x01(\x04\x12\x17\n\x0f\x63ompressed_size\x18\x02 \x01(\x04\x12\x1b\n\x13\x64\x65\x63ompressed_offset\x18\x03 \x01(\x04\x12\x19\n\x11\x64\x65\x63ompressed_size\x18\x04 \x01(\x04\"\x85\x02\n\x0cMetadataInfo\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04\x64\x61te\x18\x02 \x01(\t\x12 \n\x06\x62ucket\x18\x03 \x01(\x0e\x32\x10.metadata.Bucket\x12\x0c\n\x04path\x18\x04 \x01(\x0c\x12\x0c\n\x04size\x18\x05 \x01(\x04\x12\x0f\n\x07simhash\x18\x06 \x01(\x04\x12(\n\x08\x63hildren\x18\x07 \x03(\x0b\x32\x16.metadata.MetadataInfo\x12\"\n\x07storage\x18\x08 \x01(\x0e\x32\x11.metadata.Storage\x12&\n\x06\x66rames\x18\t \x03(\x0b\x32\x16.metadata.ArchiveFrame\x12\x16\n\x0e\x61rchive_offset\x18\n \x01(\x04*Y\n\x06\x42ucket\x12\x0c\n\x08\x44UMPSTER\x10\x00\x12\x0e\n\nLEAKS_LOGS\x10\x01\x12\x13\n\x0fLEAKS_DATABASES\x10\x02\x12\x10\n\x0c\x43OMBINATIONS\x10\x03\x12\n\n\x06PASTES\x10\x04*5\n\x07Storage\x12\x15\n\x11STORAGE_EXTRACTED\x10\x00\x12\x13\n\x0fSTORAGE_ARCHIVE\x10\x01\x62\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'proto.info.info_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   DESCRIPTOR._loaded_options = None
-  _globals['_BUCKET']._serialized_start=199
-  _globals['_BUCKET']._serialized_end=288
-  _globals['_METADATAINFO']._serialized_start=36
-  _globals['_METADATAINFO']._serialized_end=197
+  _globals['_BUCKET']._serialized_start=423
+  _globals['_BUCKET']._serialized_end=512
+  _globals['_STORAGE']._serialized_start=514
+  _globals['_STORAGE']._serialized_end=567
+  _globals['_ARCHIVEFRAME']._serialized_start=35
+  _globals['_ARCHIVEFRAME']._serialized_end=157
+  _globals['_METADATAINFO']._serialized_start=160
+  _globals['_METADATAINFO']._serialized_end=421
 # @@protoc_insertion_point(module_scope)
@@ -14,14 +14,33 @@ class Bucket(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     LEAKS_DATABASES: _ClassVar[Bucket]
     COMBINATIONS: _ClassVar[Bucket]
     PASTES: _ClassVar[Bucket]
+
+class Storage(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    STORAGE_EXTRACTED: _ClassVar[Storage]
+    STORAGE_ARCHIVE: _ClassVar[Storage]
 DUMPSTER: Bucket
 LEAKS_LOGS: Bucket
 LEAKS_DATABASES: Bucket
 COMBINATIONS: Bucket
 PASTES: Bucket
+STORAGE_EXTRACTED: Storage
+STORAGE_ARCHIVE: Storage
+
+class ArchiveFrame(_message.Message):
+    __slots__ = ("compressed_offset", "compressed_size", "decompressed_offset", "decompressed_size")
+    COMPRESSED_OFFSET_FIELD_NUMBER: _ClassVar[int]
+    COMPRESSED_SIZE_FIELD_NUMBER: _ClassVar[int]
+    DECOMPRESSED_OFFSET_FIELD_NUMBER: _ClassVar[int]
+    DECOMPRESSED_SIZE_FIELD_NUMBER: _ClassVar[int]
+    compressed_offset: int
+    compressed_size: int
+    decompressed_offset: int
+    decompressed_size: int
+    def __init__(self, compressed_offset: _Optional[int] = ..., compressed_size: _Optional[int] = ..., decompressed_offset: _Optional[int] = ..., decompressed_size: _Optional[int] = ...) -> None: ...
 
 class MetadataInfo(_message.Message):
-    __slots__ = ("id", "date", "bucket", "path", "size", "simhash", "children")
+    __slots__ = ("id", "date", "bucket", "path", "size", "simhash", "children", "storage", "frames", "archive_offset")
     ID_FIELD_NUMBER: _ClassVar[int]
     DATE_FIELD_NUMBER: _ClassVar[int]
     BUCKET_FIELD_NUMBER: _ClassVar[int]
@@ -29,6 +48,9 @@ class MetadataInfo(_message.Message):
     SIZE_FIELD_NUMBER: _ClassVar[int]
     SIMHASH_FIELD_NUMBER: _ClassVar[int]
     CHILDREN_FIELD_NUMBER: _ClassVar[int]
+    STORAGE_FIELD_NUMBER: _ClassVar[int]
+    FRAMES_FIELD_NUMBER: _ClassVar[int]
+    ARCHIVE_OFFSET_FIELD_NUMBER: _ClassVar[int]
     id: str
     date: str
     bucket: Bucket
@@ -36,4 +58,7 @@ class MetadataInfo(_message.Message):
     size: int
     simhash: int
     children: _containers.RepeatedCompositeFieldContainer[MetadataInfo]
-    def __init__(self, id: _Optional[str] = ..., date: _Optional[str] = ..., bucket: _Optional[_Union[Bucket, str]] = ..., path: _Optional[bytes] = ..., size: _Optional[int] = ..., simhash: _Optional[int] = ..., children: _Optional[_Iterable[_Union[MetadataInfo, _Mapping]]] = ...) -> None: ...
+    storage: Storage
+    frames: _containers.RepeatedCompositeFieldContainer[ArchiveFrame]
+    archive_offset: int
+    def __init__(self, id: _Optional[str] = ..., date: _Optional[str] = ..., bucket: _Optional[_Union[Bucket, str]] = ..., path: _Optional[bytes] = ..., size: _Optional[int] = ..., simhash: _Optional[int] = ..., children: _Optional[_Iterable[_Union[MetadataInfo, _Mapping]]] = ..., storage: _Optional[_Union[Storage, str]] = ..., frames: _Optional[_Iterable[_Union[ArchiveFrame, _Mapping]]] = ..., archive_offset: _Optional[int] = ...) -> None: ...
@@ -21,19 +21,1537 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Metadata struct {
+type HashConfidence int32
+
+const (
+	HashConfidence_HASH_CONFIDENCE_CERTAIN   HashConfidence = 0
+	HashConfidence_HASH_CONFIDENCE_LIKELY    HashConfidence = 1
+	HashConfidence_HASH_CONFIDENCE_AMBIGUOUS HashConfidence = 2
+)
+
+// Enum value maps for HashConfidence.
+var (
+	HashConfidence_name = map[int32]string{
+		0: "HASH_CONFIDENCE_CERTAIN",
+		1: "HASH_CONFIDENCE_LIKELY",
+		2: "HASH_CONFIDENCE_AMBIGUOUS",
+	}
+	HashConfidence_value = map[string]int32{
+		"HASH_CONFIDENCE_CERTAIN":   0,
+		"HASH_CONFIDENCE_LIKELY":    1,
+		"HASH_CONFIDENCE_AMBIGUOUS": 2,
+	}
+)
+
+func (x HashConfidence) Enum() *HashConfidence {
+	p := new(HashConfidence)
+	*p = x
+	return p
+}
+
+func (x HashConfidence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashConfidence) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[0].Descriptor()
+}
+
+func (HashConfidence) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[0]
+}
+
+func (x HashConfidence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashConfidence.Descriptor instead.
+func (HashConfidence) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{0}
+}
+
+type PasswordKind int32
+
+const (
+	PasswordKind_PASSWORD_KIND_PLAINTEXT PasswordKind = 0
+	PasswordKind_PASSWORD_KIND_HASHED    PasswordKind = 1
+	PasswordKind_PASSWORD_KIND_EMPTY     PasswordKind = 2
+)
+
+// Enum value maps for PasswordKind.
+var (
+	PasswordKind_name = map[int32]string{
+		0: "PASSWORD_KIND_PLAINTEXT",
+		1: "PASSWORD_KIND_HASHED",
+		2: "PASSWORD_KIND_EMPTY",
+	}
+	PasswordKind_value = map[string]int32{
+		"PASSWORD_KIND_PLAINTEXT": 0,
+		"PASSWORD_KIND_HASHED":    1,
+		"PASSWORD_KIND_EMPTY":     2,
+	}
+)
+
+func (x PasswordKind) Enum() *PasswordKind {
+	p := new(PasswordKind)
+	*p = x
+	return p
+}
+
+func (x PasswordKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PasswordKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[1].Descriptor()
+}
+
+func (PasswordKind) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[1]
+}
+
+func (x PasswordKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PasswordKind.Descriptor instead.
+func (PasswordKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{1}
+}
+
+type EntityOrigin int32
+
+const (
+	EntityOrigin_ENTITY_ORIGIN_TEXT             EntityOrigin = 0
+	EntityOrigin_ENTITY_ORIGIN_CSV_COLUMN       EntityOrigin = 1
+	EntityOrigin_ENTITY_ORIGIN_JSON_FIELD       EntityOrigin = 2
+	EntityOrigin_ENTITY_ORIGIN_RECORD           EntityOrigin = 3
+	EntityOrigin_ENTITY_ORIGIN_ENCODED          EntityOrigin = 4
+	EntityOrigin_ENTITY_ORIGIN_MAIL_HEADER      EntityOrigin = 5
+	EntityOrigin_ENTITY_ORIGIN_MARKUP_ATTRIBUTE EntityOrigin = 6
+	EntityOrigin_ENTITY_ORIGIN_AUTOFILL         EntityOrigin = 7
+	EntityOrigin_ENTITY_ORIGIN_HANDLE           EntityOrigin = 8
+)
+
+// Enum value maps for EntityOrigin.
+var (
+	EntityOrigin_name = map[int32]string{
+		0: "ENTITY_ORIGIN_TEXT",
+		1: "ENTITY_ORIGIN_CSV_COLUMN",
+		2: "ENTITY_ORIGIN_JSON_FIELD",
+		3: "ENTITY_ORIGIN_RECORD",
+		4: "ENTITY_ORIGIN_ENCODED",
+		5: "ENTITY_ORIGIN_MAIL_HEADER",
+		6: "ENTITY_ORIGIN_MARKUP_ATTRIBUTE",
+		7: "ENTITY_ORIGIN_AUTOFILL",
+		8: "ENTITY_ORIGIN_HANDLE",
+	}
+	EntityOrigin_value = map[string]int32{
+		"ENTITY_ORIGIN_TEXT":             0,
+		"ENTITY_ORIGIN_CSV_COLUMN":       1,
+		"ENTITY_ORIGIN_JSON_FIELD":       2,
+		"ENTITY_ORIGIN_RECORD":           3,
+		"ENTITY_ORIGIN_ENCODED":          4,
+		"ENTITY_ORIGIN_MAIL_HEADER":      5,
+		"ENTITY_ORIGIN_MARKUP_ATTRIBUTE": 6,
+		"ENTITY_ORIGIN_AUTOFILL":         7,
+		"ENTITY_ORIGIN_HANDLE":           8,
+	}
+)
+
+func (x EntityOrigin) Enum() *EntityOrigin {
+	p := new(EntityOrigin)
+	*p = x
+	return p
+}
+
+func (x EntityOrigin) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityOrigin) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[2].Descriptor()
+}
+
+func (EntityOrigin) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[2]
+}
+
+func (x EntityOrigin) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityOrigin.Descriptor instead.
+func (EntityOrigin) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{2}
+}
+
+type IpClass int32
+
+const (
+	IpClass_IP_CLASS_PUBLIC        IpClass = 0
+	IpClass_IP_CLASS_PRIVATE       IpClass = 1
+	IpClass_IP_CLASS_CGNAT         IpClass = 2
+	IpClass_IP_CLASS_RESERVED      IpClass = 3
+	IpClass_IP_CLASS_LOOPBACK      IpClass = 4
+	IpClass_IP_CLASS_LINK_LOCAL    IpClass = 5
+	IpClass_IP_CLASS_MULTICAST     IpClass = 6
+	IpClass_IP_CLASS_DOCUMENTATION IpClass = 7
+)
+
+// Enum value maps for IpClass.
+var (
+	IpClass_name = map[int32]string{
+		0: "IP_CLASS_PUBLIC",
+		1: "IP_CLASS_PRIVATE",
+		2: "IP_CLASS_CGNAT",
+		3: "IP_CLASS_RESERVED",
+		4: "IP_CLASS_LOOPBACK",
+		5: "IP_CLASS_LINK_LOCAL",
+		6: "IP_CLASS_MULTICAST",
+		7: "IP_CLASS_DOCUMENTATION",
+	}
+	IpClass_value = map[string]int32{
+		"IP_CLASS_PUBLIC":        0,
+		"IP_CLASS_PRIVATE":       1,
+		"IP_CLASS_CGNAT":         2,
+		"IP_CLASS_RESERVED":      3,
+		"IP_CLASS_LOOPBACK":      4,
+		"IP_CLASS_LINK_LOCAL":    5,
+		"IP_CLASS_MULTICAST":     6,
+		"IP_CLASS_DOCUMENTATION": 7,
+	}
+)
+
+func (x IpClass) Enum() *IpClass {
+	p := new(IpClass)
+	*p = x
+	return p
+}
+
+func (x IpClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IpClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[3].Descriptor()
+}
+
+func (IpClass) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[3]
+}
+
+func (x IpClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IpClass.Descriptor instead.
+func (IpClass) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{3}
+}
+
+type EmailCategory int32
+
+const (
+	EmailCategory_EMAIL_CATEGORY_CORPORATE  EmailCategory = 0
+	EmailCategory_EMAIL_CATEGORY_FREEMAIL   EmailCategory = 1
+	EmailCategory_EMAIL_CATEGORY_DISPOSABLE EmailCategory = 2
+)
+
+// Enum value maps for EmailCategory.
+var (
+	EmailCategory_name = map[int32]string{
+		0: "EMAIL_CATEGORY_CORPORATE",
+		1: "EMAIL_CATEGORY_FREEMAIL",
+		2: "EMAIL_CATEGORY_DISPOSABLE",
+	}
+	EmailCategory_value = map[string]int32{
+		"EMAIL_CATEGORY_CORPORATE":  0,
+		"EMAIL_CATEGORY_FREEMAIL":   1,
+		"EMAIL_CATEGORY_DISPOSABLE": 2,
+	}
+)
+
+func (x EmailCategory) Enum() *EmailCategory {
+	p := new(EmailCategory)
+	*p = x
+	return p
+}
+
+func (x EmailCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmailCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[4].Descriptor()
+}
+
+func (EmailCategory) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[4]
+}
+
+func (x EmailCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmailCategory.Descriptor instead.
+func (EmailCategory) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{4}
+}
+
+type PrivacyMode int32
+
+const (
+	PrivacyMode_PRIVACY_MODE_PLAINTEXT PrivacyMode = 0
+	PrivacyMode_PRIVACY_MODE_HMAC      PrivacyMode = 1
+)
+
+// Enum value maps for PrivacyMode.
+var (
+	PrivacyMode_name = map[int32]string{
+		0: "PRIVACY_MODE_PLAINTEXT",
+		1: "PRIVACY_MODE_HMAC",
+	}
+	PrivacyMode_value = map[string]int32{
+		"PRIVACY_MODE_PLAINTEXT": 0,
+		"PRIVACY_MODE_HMAC":      1,
+	}
+)
+
+func (x PrivacyMode) Enum() *PrivacyMode {
+	p := new(PrivacyMode)
+	*p = x
+	return p
+}
+
+func (x PrivacyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrivacyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_metadata_metadata_proto_enumTypes[5].Descriptor()
+}
+
+func (PrivacyMode) Type() protoreflect.EnumType {
+	return &file_proto_metadata_metadata_proto_enumTypes[5]
+}
+
+func (x PrivacyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrivacyMode.Descriptor instead.
+func (PrivacyMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{5}
+}
+
+type PaymentCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Masked        []byte                 `protobuf:"bytes,1,opt,name=masked,proto3" json:"masked,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentCard) Reset() {
+	*x = PaymentCard{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentCard) ProtoMessage() {}
+
+func (x *PaymentCard) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentCard.ProtoReflect.Descriptor instead.
+func (*PaymentCard) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PaymentCard) GetMasked() []byte {
+	if x != nil {
+		return x.Masked
+	}
+	return nil
+}
+
+func (x *PaymentCard) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *PaymentCard) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type Iban struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Masked        []byte                 `protobuf:"bytes,1,opt,name=masked,proto3" json:"masked,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Iban) Reset() {
+	*x = Iban{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Iban) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Iban) ProtoMessage() {}
+
+func (x *Iban) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Iban.ProtoReflect.Descriptor instead.
+func (*Iban) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Iban) GetMasked() []byte {
+	if x != nil {
+		return x.Masked
+	}
+	return nil
+}
+
+func (x *Iban) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Iban) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type HashCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Confidence    HashConfidence         `protobuf:"varint,2,opt,name=confidence,proto3,enum=metadata.HashConfidence" json:"confidence,omitempty"`
+	Count         uint64                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Alternatives  []string               `protobuf:"bytes,4,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashCount) Reset() {
+	*x = HashCount{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashCount) ProtoMessage() {}
+
+func (x *HashCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashCount.ProtoReflect.Descriptor instead.
+func (*HashCount) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HashCount) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *HashCount) GetConfidence() HashConfidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return HashConfidence_HASH_CONFIDENCE_CERTAIN
+}
+
+func (x *HashCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HashCount) GetAlternatives() []string {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+type Secret struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Preview       []byte                 `protobuf:"bytes,2,opt,name=preview,proto3" json:"preview,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Entropy       float64                `protobuf:"fixed64,4,opt,name=entropy,proto3" json:"entropy,omitempty"`
+	JwtIssuer     string                 `protobuf:"bytes,5,opt,name=jwt_issuer,json=jwtIssuer,proto3" json:"jwt_issuer,omitempty"`
+	JwtSubject    string                 `protobuf:"bytes,6,opt,name=jwt_subject,json=jwtSubject,proto3" json:"jwt_subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Secret) Reset() {
+	*x = Secret{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Secret) ProtoMessage() {}
+
+func (x *Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
+func (*Secret) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Secret) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Secret) GetPreview() []byte {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+func (x *Secret) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Secret) GetEntropy() float64 {
+	if x != nil {
+		return x.Entropy
+	}
+	return 0
+}
+
+func (x *Secret) GetJwtIssuer() string {
+	if x != nil {
+		return x.JwtIssuer
+	}
+	return ""
+}
+
+func (x *Secret) GetJwtSubject() string {
+	if x != nil {
+		return x.JwtSubject
+	}
+	return ""
+}
+
+type Credential struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         []byte                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	PasswordKind  PasswordKind           `protobuf:"varint,2,opt,name=password_kind,json=passwordKind,proto3,enum=metadata.PasswordKind" json:"password_kind,omitempty"`
+	PasswordHash  []byte                 `protobuf:"bytes,3,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	HashAlgorithm string                 `protobuf:"bytes,4,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hash_algorithm,omitempty"`
+	Record        uint64                 `protobuf:"varint,5,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Credential) Reset() {
+	*x = Credential{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credential) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credential) ProtoMessage() {}
+
+func (x *Credential) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credential.ProtoReflect.Descriptor instead.
+func (*Credential) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Credential) GetEmail() []byte {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *Credential) GetPasswordKind() PasswordKind {
+	if x != nil {
+		return x.PasswordKind
+	}
+	return PasswordKind_PASSWORD_KIND_PLAINTEXT
+}
+
+func (x *Credential) GetPasswordHash() []byte {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return nil
+}
+
+func (x *Credential) GetHashAlgorithm() string {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return ""
+}
+
+func (x *Credential) GetRecord() uint64 {
+	if x != nil {
+		return x.Record
+	}
+	return 0
+}
+
+type SourcedEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Origin        EntityOrigin           `protobuf:"varint,3,opt,name=origin,proto3,enum=metadata.EntityOrigin" json:"origin,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Record        uint64                 `protobuf:"varint,5,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourcedEntity) Reset() {
+	*x = SourcedEntity{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourcedEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourcedEntity) ProtoMessage() {}
+
+func (x *SourcedEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourcedEntity.ProtoReflect.Descriptor instead.
+func (*SourcedEntity) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SourcedEntity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SourcedEntity) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SourcedEntity) GetOrigin() EntityOrigin {
+	if x != nil {
+		return x.Origin
+	}
+	return EntityOrigin_ENTITY_ORIGIN_TEXT
+}
+
+func (x *SourcedEntity) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SourcedEntity) GetRecord() uint64 {
+	if x != nil {
+		return x.Record
+	}
+	return 0
+}
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      []byte                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         []byte                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Record        uint64                 `protobuf:"varint,4,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Account) GetUsername() []byte {
+	if x != nil {
+		return x.Username
+	}
+	return nil
+}
+
+func (x *Account) GetEmail() []byte {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *Account) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Account) GetRecord() uint64 {
+	if x != nil {
+		return x.Record
+	}
+	return 0
+}
+
+type IpInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            []byte                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Class         IpClass                `protobuf:"varint,2,opt,name=class,proto3,enum=metadata.IpClass" json:"class,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Asn           uint32                 `protobuf:"varint,4,opt,name=asn,proto3" json:"asn,omitempty"`
+	Organization  string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IpInfo) Reset() {
+	*x = IpInfo{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IpInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IpInfo) ProtoMessage() {}
+
+func (x *IpInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IpInfo.ProtoReflect.Descriptor instead.
+func (*IpInfo) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IpInfo) GetIp() []byte {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *IpInfo) GetClass() IpClass {
+	if x != nil {
+		return x.Class
+	}
+	return IpClass_IP_CLASS_PUBLIC
+}
+
+func (x *IpInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *IpInfo) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *IpInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+type EmailInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         []byte                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Category      EmailCategory          `protobuf:"varint,2,opt,name=category,proto3,enum=metadata.EmailCategory" json:"category,omitempty"`
+	Role          bool                   `protobuf:"varint,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailInfo) Reset() {
+	*x = EmailInfo{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailInfo) ProtoMessage() {}
+
+func (x *EmailInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailInfo.ProtoReflect.Descriptor instead.
+func (*EmailInfo) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EmailInfo) GetEmail() []byte {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *EmailInfo) GetCategory() EmailCategory {
+	if x != nil {
+		return x.Category
+	}
+	return EmailCategory_EMAIL_CATEGORY_CORPORATE
+}
+
+func (x *EmailInfo) GetRole() bool {
+	if x != nil {
+		return x.Role
+	}
+	return false
+}
+
+type Cookie struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        []byte                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Expiry        uint64                 `protobuf:"varint,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Secure        bool                   `protobuf:"varint,4,opt,name=secure,proto3" json:"secure,omitempty"`
+	HttpOnly      bool                   `protobuf:"varint,5,opt,name=http_only,json=httpOnly,proto3" json:"http_only,omitempty"`
+	ValueHash     []byte                 `protobuf:"bytes,6,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cookie) Reset() {
+	*x = Cookie{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cookie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cookie) ProtoMessage() {}
+
+func (x *Cookie) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cookie.ProtoReflect.Descriptor instead.
+func (*Cookie) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Cookie) GetDomain() []byte {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+func (x *Cookie) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cookie) GetExpiry() uint64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+func (x *Cookie) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+func (x *Cookie) GetHttpOnly() bool {
+	if x != nil {
+		return x.HttpOnly
+	}
+	return false
+}
+
+func (x *Cookie) GetValueHash() []byte {
+	if x != nil {
+		return x.ValueHash
+	}
+	return nil
+}
+
+type AutofillField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Record        uint64                 `protobuf:"varint,3,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutofillField) Reset() {
+	*x = AutofillField{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutofillField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutofillField) ProtoMessage() {}
+
+func (x *AutofillField) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutofillField.ProtoReflect.Descriptor instead.
+func (*AutofillField) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AutofillField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AutofillField) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *AutofillField) GetRecord() uint64 {
+	if x != nil {
+		return x.Record
+	}
+	return 0
+}
+
+type BrowserArtifacts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cookies       []*Cookie              `protobuf:"bytes,1,rep,name=cookies,proto3" json:"cookies,omitempty"`
+	Autofill      []*AutofillField       `protobuf:"bytes,2,rep,name=autofill,proto3" json:"autofill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowserArtifacts) Reset() {
+	*x = BrowserArtifacts{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowserArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowserArtifacts) ProtoMessage() {}
+
+func (x *BrowserArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowserArtifacts.ProtoReflect.Descriptor instead.
+func (*BrowserArtifacts) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BrowserArtifacts) GetCookies() []*Cookie {
+	if x != nil {
+		return x.Cookies
+	}
+	return nil
+}
+
+func (x *BrowserArtifacts) GetAutofill() []*AutofillField {
+	if x != nil {
+		return x.Autofill
+	}
+	return nil
+}
+
+type SchemaField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaField) Reset() {
+	*x = SchemaField{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaField) ProtoMessage() {}
+
+func (x *SchemaField) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaField.ProtoReflect.Descriptor instead.
+func (*SchemaField) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SchemaField) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SchemaField) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NamedCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamedCount) Reset() {
+	*x = NamedCount{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamedCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedCount) ProtoMessage() {}
+
+func (x *NamedCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedCount.ProtoReflect.Descriptor instead.
+func (*NamedCount) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NamedCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamedCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PartStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Lines            uint64                 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	Bytes            uint64                 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	EmptyLines       uint64                 `protobuf:"varint,3,opt,name=empty_lines,json=emptyLines,proto3" json:"empty_lines,omitempty"`
+	BinaryLines      uint64                 `protobuf:"varint,4,opt,name=binary_lines,json=binaryLines,proto3" json:"binary_lines,omitempty"`
+	LongestLine      uint64                 `protobuf:"varint,5,opt,name=longest_line,json=longestLine,proto3" json:"longest_line,omitempty"`
+	Delimiter        string                 `protobuf:"bytes,6,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Matches          []*NamedCount          `protobuf:"bytes,7,rep,name=matches,proto3" json:"matches,omitempty"`
+	Records          uint64                 `protobuf:"varint,8,opt,name=records,proto3" json:"records,omitempty"`
+	EarliestDate     uint64                 `protobuf:"varint,9,opt,name=earliest_date,json=earliestDate,proto3" json:"earliest_date,omitempty"`
+	LatestDate       uint64                 `protobuf:"varint,10,opt,name=latest_date,json=latestDate,proto3" json:"latest_date,omitempty"`
+	Filtered         []*NamedCount          `protobuf:"bytes,11,rep,name=filtered,proto3" json:"filtered,omitempty"`
+	InvalidDocuments uint64                 `protobuf:"varint,12,opt,name=invalid_documents,json=invalidDocuments,proto3" json:"invalid_documents,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PartStats) Reset() {
+	*x = PartStats{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartStats) ProtoMessage() {}
+
+func (x *PartStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartStats.ProtoReflect.Descriptor instead.
+func (*PartStats) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PartStats) GetLines() uint64 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *PartStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *PartStats) GetEmptyLines() uint64 {
+	if x != nil {
+		return x.EmptyLines
+	}
+	return 0
+}
+
+func (x *PartStats) GetBinaryLines() uint64 {
+	if x != nil {
+		return x.BinaryLines
+	}
+	return 0
+}
+
+func (x *PartStats) GetLongestLine() uint64 {
+	if x != nil {
+		return x.LongestLine
+	}
+	return 0
+}
+
+func (x *PartStats) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *PartStats) GetMatches() []*NamedCount {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *PartStats) GetRecords() uint64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *PartStats) GetEarliestDate() uint64 {
+	if x != nil {
+		return x.EarliestDate
+	}
+	return 0
+}
+
+func (x *PartStats) GetLatestDate() uint64 {
+	if x != nil {
+		return x.LatestDate
+	}
+	return 0
+}
+
+func (x *PartStats) GetFiltered() []*NamedCount {
+	if x != nil {
+		return x.Filtered
+	}
+	return nil
+}
+
+func (x *PartStats) GetInvalidDocuments() uint64 {
+	if x != nil {
+		return x.InvalidDocuments
+	}
+	return 0
+}
+
+type DirectorySummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parts         uint64                 `protobuf:"varint,1,opt,name=parts,proto3" json:"parts,omitempty"`
+	FailedParts   uint64                 `protobuf:"varint,2,opt,name=failed_parts,json=failedParts,proto3" json:"failed_parts,omitempty"`
+	Totals        *PartStats             `protobuf:"bytes,3,opt,name=totals,proto3" json:"totals,omitempty"`
+	Delimiters    []*NamedCount          `protobuf:"bytes,4,rep,name=delimiters,proto3" json:"delimiters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DirectorySummary) Reset() {
+	*x = DirectorySummary{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DirectorySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectorySummary) ProtoMessage() {}
+
+func (x *DirectorySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectorySummary.ProtoReflect.Descriptor instead.
+func (*DirectorySummary) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DirectorySummary) GetParts() uint64 {
+	if x != nil {
+		return x.Parts
+	}
+	return 0
+}
+
+func (x *DirectorySummary) GetFailedParts() uint64 {
+	if x != nil {
+		return x.FailedParts
+	}
+	return 0
+}
+
+func (x *DirectorySummary) GetTotals() *PartStats {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *DirectorySummary) GetDelimiters() []*NamedCount {
+	if x != nil {
+		return x.Delimiters
+	}
+	return nil
+}
+
+type Privacy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Emails        [][]byte               `protobuf:"bytes,2,rep,name=emails,proto3" json:"emails,omitempty"`
-	Ips           [][]byte               `protobuf:"bytes,3,rep,name=ips,proto3" json:"ips,omitempty"`
-	Domains       [][]byte               `protobuf:"bytes,4,rep,name=domains,proto3" json:"domains,omitempty"`
+	Mode          PrivacyMode            `protobuf:"varint,1,opt,name=mode,proto3,enum=metadata.PrivacyMode" json:"mode,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Privacy) Reset() {
+	*x = Privacy{}
+	mi := &file_proto_metadata_metadata_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Privacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Privacy) ProtoMessage() {}
+
+func (x *Privacy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_metadata_metadata_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Privacy.ProtoReflect.Descriptor instead.
+func (*Privacy) Descriptor() ([]byte, []int) {
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Privacy) GetMode() PrivacyMode {
+	if x != nil {
+		return x.Mode
+	}
+	return PrivacyMode_PRIVACY_MODE_PLAINTEXT
+}
+
+func (x *Privacy) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type Metadata struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Emails           [][]byte               `protobuf:"bytes,2,rep,name=emails,proto3" json:"emails,omitempty"`
+	Ips              [][]byte               `protobuf:"bytes,3,rep,name=ips,proto3" json:"ips,omitempty"`
+	Domains          [][]byte               `protobuf:"bytes,4,rep,name=domains,proto3" json:"domains,omitempty"`
+	Cards            []*PaymentCard         `protobuf:"bytes,5,rep,name=cards,proto3" json:"cards,omitempty"`
+	Ibans            []*Iban                `protobuf:"bytes,6,rep,name=ibans,proto3" json:"ibans,omitempty"`
+	Hashes           []*HashCount           `protobuf:"bytes,7,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Secrets          []*Secret              `protobuf:"bytes,8,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	Credentials      []*Credential          `protobuf:"bytes,9,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	SourcedEntities  []*SourcedEntity       `protobuf:"bytes,10,rep,name=sourced_entities,json=sourcedEntities,proto3" json:"sourced_entities,omitempty"`
+	Schema           []*SchemaField         `protobuf:"bytes,11,rep,name=schema,proto3" json:"schema,omitempty"`
+	PartHash         uint64                 `protobuf:"varint,12,opt,name=part_hash,json=partHash,proto3" json:"part_hash,omitempty"`
+	ExtractorVersion uint32                 `protobuf:"varint,13,opt,name=extractor_version,json=extractorVersion,proto3" json:"extractor_version,omitempty"`
+	Stats            *PartStats             `protobuf:"bytes,14,opt,name=stats,proto3" json:"stats,omitempty"`
+	BrowserArtifacts *BrowserArtifacts      `protobuf:"bytes,15,opt,name=browser_artifacts,json=browserArtifacts,proto3" json:"browser_artifacts,omitempty"`
+	Accounts         []*Account             `protobuf:"bytes,16,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	IpInfos          []*IpInfo              `protobuf:"bytes,17,rep,name=ip_infos,json=ipInfos,proto3" json:"ip_infos,omitempty"`
+	EmailInfos       []*EmailInfo           `protobuf:"bytes,18,rep,name=email_infos,json=emailInfos,proto3" json:"email_infos,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *Metadata) Reset() {
 	*x = Metadata{}
-	mi := &file_proto_metadata_metadata_proto_msgTypes[0]
+	mi := &file_proto_metadata_metadata_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +1563,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_metadata_metadata_proto_msgTypes[0]
+	mi := &file_proto_metadata_metadata_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +1576,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{0}
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Metadata) GetId() string {
@@ -89,16 +1607,117 @@ func (x *Metadata) GetDomains() [][]byte {
 	return nil
 }
 
+func (x *Metadata) GetCards() []*PaymentCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Metadata) GetIbans() []*Iban {
+	if x != nil {
+		return x.Ibans
+	}
+	return nil
+}
+
+func (x *Metadata) GetHashes() []*HashCount {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *Metadata) GetSecrets() []*Secret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *Metadata) GetCredentials() []*Credential {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *Metadata) GetSourcedEntities() []*SourcedEntity {
+	if x != nil {
+		return x.SourcedEntities
+	}
+	return nil
+}
+
+func (x *Metadata) GetSchema() []*SchemaField {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *Metadata) GetPartHash() uint64 {
+	if x != nil {
+		return x.PartHash
+	}
+	return 0
+}
+
+func (x *Metadata) GetExtractorVersion() uint32 {
+	if x != nil {
+		return x.ExtractorVersion
+	}
+	return 0
+}
+
+func (x *Metadata) GetStats() *PartStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Metadata) GetBrowserArtifacts() *BrowserArtifacts {
+	if x != nil {
+		return x.BrowserArtifacts
+	}
+	return nil
+}
+
+func (x *Metadata) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *Metadata) GetIpInfos() []*IpInfo {
+	if x != nil {
+		return x.IpInfos
+	}
+	return nil
+}
+
+func (x *Metadata) GetEmailInfos() []*EmailInfo {
+	if x != nil {
+		return x.EmailInfos
+	}
+	return nil
+}
+
 type MetadataList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*Metadata            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Items              []*Metadata            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Summary            *DirectorySummary      `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Privacy            *Privacy               `protobuf:"bytes,3,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	OptionsFingerprint string                 `protobuf:"bytes,4,opt,name=options_fingerprint,json=optionsFingerprint,proto3" json:"options_fingerprint,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *MetadataList) Reset() {
 	*x = MetadataList{}
-	mi := &file_proto_metadata_metadata_proto_msgTypes[1]
+	mi := &file_proto_metadata_metadata_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -110,7 +1729,7 @@ func (x *MetadataList) String() string {
 func (*MetadataList) ProtoMessage() {}
 
 func (x *MetadataList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_metadata_metadata_proto_msgTypes[1]
+	mi := &file_proto_metadata_metadata_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +1742,7 @@ func (x *MetadataList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetadataList.ProtoReflect.Descriptor instead.
 func (*MetadataList) Descriptor() ([]byte, []int) {
-	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{1}
+	return file_proto_metadata_metadata_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MetadataList) GetItems() []*Metadata {
@@ -133,22 +1752,296 @@ func (x *MetadataList) GetItems() []*Metadata {
 	return nil
 }
 
+func (x *MetadataList) GetSummary() *DirectorySummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *MetadataList) GetPrivacy() *Privacy {
+	if x != nil {
+		return x.Privacy
+	}
+	return nil
+}
+
+func (x *MetadataList) GetOptionsFingerprint() string {
+	if x != nil {
+		return x.OptionsFingerprint
+	}
+	return ""
+}
+
 var File_proto_metadata_metadata_proto protoreflect.FileDescriptor
 
 var file_proto_metadata_metadata_proto_rawDesc = string([]byte{
 	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5e, 0x0a, 0x08, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x10, 0x0a,
-	0x03, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65,
-	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x53, 0x0a, 0x0b, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x6b,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x4c,
+	0x0a, 0x04, 0x49, 0x62, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x9d, 0x01, 0x0a,
+	0x09, 0x48, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x73, 0x22, 0xa4, 0x01, 0x0a,
+	0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x6f, 0x70, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x6f, 0x70, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x77, 0x74, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x77, 0x74, 0x49, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x77, 0x74, 0x53, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x22, 0xc3, 0x01, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x3b, 0x0a, 0x0d, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x16, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0c, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61,
+	0x73, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x99, 0x01, 0x0a, 0x0d, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x6b, 0x0a, 0x07, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x06, 0x49, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x70, 0x12, 0x27, 0x0a,
+	0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x49, 0x70, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52,
+	0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61,
+	0x73, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6a, 0x0a, 0x09, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x74, 0x74,
+	0x70, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x74,
+	0x74, 0x70, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x48, 0x61, 0x73, 0x68, 0x22, 0x51, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c,
+	0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x73, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x77,
+	0x73, 0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x07,
+	0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x52,
+	0x07, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f,
+	0x66, 0x69, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x22, 0x37, 0x0a,
+	0x0b, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x36, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xab,
+	0x03, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6e,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x2e, 0x0a,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x61, 0x72, 0x6c, 0x69,
+	0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x65, 0x61, 0x72, 0x6c, 0x69, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a,
+	0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12,
+	0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x69, 0x6e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xae, 0x01, 0x0a,
+	0x10, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x72, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x73, 0x22, 0x4b, 0x0a,
+	0x07, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x22, 0x85, 0x06, 0x0a, 0x08, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x69, 0x70,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x72,
+	0x64, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x62, 0x61, 0x6e,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x2e, 0x49, 0x62, 0x61, 0x6e, 0x52, 0x05, 0x69, 0x62, 0x61, 0x6e, 0x73, 0x12, 0x2b,
+	0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x07,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12,
+	0x42, 0x0a, 0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x52, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x61, 0x72, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x2b, 0x0a, 0x11, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x47, 0x0a, 0x11, 0x62, 0x72, 0x6f, 0x77, 0x73,
+	0x65, 0x72, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x72,
+	0x6f, 0x77, 0x73, 0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x10,
+	0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x12, 0x2d, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x2b, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x49, 0x70, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x69, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x34, 0x0a, 0x0b,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x6e, 0x66,
+	0x6f, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x34, 0x0a,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79,
+	0x12, 0x2f, 0x0a, 0x13, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x2a, 0x68, 0x0a, 0x0e, 0x48, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x46,
+	0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x41, 0x49, 0x4e, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x16, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45,
+	0x4e, 0x43, 0x45, 0x5f, 0x4c, 0x49, 0x4b, 0x45, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19,
+	0x48, 0x41, 0x53, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f,
+	0x41, 0x4d, 0x42, 0x49, 0x47, 0x55, 0x4f, 0x55, 0x53, 0x10, 0x02, 0x2a, 0x5e, 0x0a, 0x0c, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x17, 0x50,
+	0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x50, 0x4c, 0x41,
+	0x49, 0x4e, 0x54, 0x45, 0x58, 0x54, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x41, 0x53, 0x53,
+	0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x02, 0x2a, 0x90, 0x02, 0x0a, 0x0c,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x12,
+	0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x54, 0x45,
+	0x58, 0x54, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f,
+	0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x43, 0x53, 0x56, 0x5f, 0x43, 0x4f, 0x4c, 0x55, 0x4d, 0x4e,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x52, 0x49,
+	0x47, 0x49, 0x4e, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x10, 0x02,
+	0x12, 0x18, 0x0a, 0x14, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x52, 0x49, 0x47, 0x49,
+	0x4e, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x4e,
+	0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x45, 0x4e, 0x43, 0x4f,
+	0x44, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f,
+	0x4f, 0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x48, 0x45, 0x41, 0x44,
+	0x45, 0x52, 0x10, 0x05, 0x12, 0x22, 0x0a, 0x1e, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f,
+	0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x4d, 0x41, 0x52, 0x4b, 0x55, 0x50, 0x5f, 0x41, 0x54, 0x54,
+	0x52, 0x49, 0x42, 0x55, 0x54, 0x45, 0x10, 0x06, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x4e, 0x54, 0x49,
+	0x54, 0x59, 0x5f, 0x4f, 0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x46, 0x49,
+	0x4c, 0x4c, 0x10, 0x07, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4f,
+	0x52, 0x49, 0x47, 0x49, 0x4e, 0x5f, 0x48, 0x41, 0x4e, 0x44, 0x4c, 0x45, 0x10, 0x08, 0x2a, 0xc3,
+	0x01, 0x0a, 0x07, 0x49, 0x70, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x50,
+	0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x00, 0x12,
+	0x14, 0x0a, 0x10, 0x49, 0x50, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x50, 0x52, 0x49, 0x56,
+	0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x50, 0x5f, 0x43, 0x4c, 0x41, 0x53,
+	0x53, 0x5f, 0x43, 0x47, 0x4e, 0x41, 0x54, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x49, 0x50, 0x5f,
+	0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x03,
+	0x12, 0x15, 0x0a, 0x11, 0x49, 0x50, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4c, 0x4f, 0x4f,
+	0x50, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x50, 0x5f, 0x43, 0x4c,
+	0x41, 0x53, 0x53, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x05,
+	0x12, 0x16, 0x0a, 0x12, 0x49, 0x50, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4d, 0x55, 0x4c,
+	0x54, 0x49, 0x43, 0x41, 0x53, 0x54, 0x10, 0x06, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x50, 0x5f, 0x43,
+	0x4c, 0x41, 0x53, 0x53, 0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x07, 0x2a, 0x69, 0x0a, 0x0d, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x43,
+	0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x43, 0x4f, 0x52, 0x50, 0x4f, 0x52, 0x41, 0x54,
+	0x45, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x43, 0x41, 0x54,
+	0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x46, 0x52, 0x45, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x01,
+	0x12, 0x1d, 0x0a, 0x19, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f,
+	0x52, 0x59, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4f, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x2a,
+	0x40, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1a,
+	0x0a, 0x16, 0x50, 0x52, 0x49, 0x56, 0x41, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50,
+	0x4c, 0x41, 0x49, 0x4e, 0x54, 0x45, 0x58, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52,
+	0x49, 0x56, 0x41, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x48, 0x4d, 0x41, 0x43, 0x10,
+	0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -163,18 +2056,68 @@ func file_proto_metadata_metadata_proto_rawDescGZIP() []byte {
 	return file_proto_metadata_metadata_proto_rawDescData
 }
 
-var file_proto_metadata_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_metadata_metadata_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_metadata_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_metadata_metadata_proto_goTypes = []any{
-	(*Metadata)(nil),     // 0: metadata.Metadata
-	(*MetadataList)(nil), // 1: metadata.MetadataList
+	(HashConfidence)(0),      // 0: metadata.HashConfidence
+	(PasswordKind)(0),        // 1: metadata.PasswordKind
+	(EntityOrigin)(0),        // 2: metadata.EntityOrigin
+	(IpClass)(0),             // 3: metadata.IpClass
+	(EmailCategory)(0),       // 4: metadata.EmailCategory
+	(PrivacyMode)(0),         // 5: metadata.PrivacyMode
+	(*PaymentCard)(nil),      // 6: metadata.PaymentCard
+	(*Iban)(nil),             // 7: metadata.Iban
+	(*HashCount)(nil),        // 8: metadata.HashCount
+	(*Secret)(nil),           // 9: metadata.Secret
+	(*Credential)(nil),       // 10: metadata.Credential
+	(*SourcedEntity)(nil),    // 11: metadata.SourcedEntity
+	(*Account)(nil),          // 12: metadata.Account
+	(*IpInfo)(nil),           // 13: metadata.IpInfo
+	(*EmailInfo)(nil),        // 14: metadata.EmailInfo
+	(*Cookie)(nil),           // 15: metadata.Cookie
+	(*AutofillField)(nil),    // 16: metadata.AutofillField
+	(*BrowserArtifacts)(nil), // 17: metadata.BrowserArtifacts
+	(*SchemaField)(nil),      // 18: metadata.SchemaField
+	(*NamedCount)(nil),       // 19: metadata.NamedCount
+	(*PartStats)(nil),        // 20: metadata.PartStats
+	(*DirectorySummary)(nil), // 21: metadata.DirectorySummary
+	(*Privacy)(nil),          // 22: metadata.Privacy
+	(*Metadata)(nil),         // 23: metadata.Metadata
+	(*MetadataList)(nil),     // 24: metadata.MetadataList
 }
 var file_proto_metadata_metadata_proto_depIdxs = []int32{
-	0, // 0: metadata.MetadataList.items:type_name -> metadata.Metadata
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: metadata.HashCount.confidence:type_name -> metadata.HashConfidence
+	1,  // 1: metadata.Credential.password_kind:type_name -> metadata.PasswordKind
+	2,  // 2: metadata.SourcedEntity.origin:type_name -> metadata.EntityOrigin
+	3,  // 3: metadata.IpInfo.class:type_name -> metadata.IpClass
+	4,  // 4: metadata.EmailInfo.category:type_name -> metadata.EmailCategory
+	15, // 5: metadata.BrowserArtifacts.cookies:type_name -> metadata.Cookie
+	16, // 6: metadata.BrowserArtifacts.autofill:type_name -> metadata.AutofillField
+	19, // 7: metadata.PartStats.matches:type_name -> metadata.NamedCount
+	19, // 8: metadata.PartStats.filtered:type_name -> metadata.NamedCount
+	20, // 9: metadata.DirectorySummary.totals:type_name -> metadata.PartStats
+	19, // 10: metadata.DirectorySummary.delimiters:type_name -> metadata.NamedCount
+	5,  // 11: metadata.Privacy.mode:type_name -> metadata.PrivacyMode
+	6,  // 12: metadata.Metadata.cards:type_name -> metadata.PaymentCard
+	7,  // 13: metadata.Metadata.ibans:type_name -> metadata.Iban
+	8,  // 14: metadata.Metadata.hashes:type_name -> metadata.HashCount
+	9,  // 15: metadata.Metadata.secrets:type_name -> metadata.Secret
+	10, // 16: metadata.Metadata.credentials:type_name -> metadata.Credential
+	11, // 17: metadata.Metadata.sourced_entities:type_name -> metadata.SourcedEntity
+	18, // 18: metadata.Metadata.schema:type_name -> metadata.SchemaField
+	20, // 19: metadata.Metadata.stats:type_name -> metadata.PartStats
+	17, // 20: metadata.Metadata.browser_artifacts:type_name -> metadata.BrowserArtifacts
+	12, // 21: metadata.Metadata.accounts:type_name -> metadata.Account
+	13, // 22: metadata.Metadata.ip_infos:type_name -> metadata.IpInfo
+	14, // 23: metadata.Metadata.email_infos:type_name -> metadata.EmailInfo
+	23, // 24: metadata.MetadataList.items:type_name -> metadata.Metadata
+	21, // 25: metadata.MetadataList.summary:type_name -> metadata.DirectorySummary
+	22, // 26: metadata.MetadataList.privacy:type_name -> metadata.Privacy
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_proto_metadata_metadata_proto_init() }
@@ -187,13 +2130,14 @@ func file_proto_metadata_metadata_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_metadata_metadata_proto_rawDesc), len(file_proto_metadata_metadata_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      6,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_metadata_metadata_proto_goTypes,
 		DependencyIndexes: file_proto_metadata_metadata_proto_depIdxs,
+		EnumInfos:         file_proto_metadata_metadata_proto_enumTypes,
 		MessageInfos:      file_proto_metadata_metadata_proto_msgTypes,
 	}.Build()
 	File_proto_metadata_metadata_proto = out.File
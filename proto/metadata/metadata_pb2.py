@@ -24,15 +24,61 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1dproto/metadata/metadata.proto\x12\x08metadata\"D\n\x08Metadata\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06\x65mails\x18\x02 \x03(\x0c\x12\x0b\n\x03ips\x18\x03 \x03(\x0c\x12\x0f\n\x07\x64omains\x18\x04 \x03(\x0c\"1\n\x0cMetadataList\x12!\n\x05items\x18\x01 \x03(\x0b\x32\x12.metadata.Metadatab\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1dproto/metadata/metadata.proto\x12\x08metadata\"<\n\x0bPaymentCard\x12\x0e\n\x06masked\x18\x01 \x01(\x0c\x12\x0c\n\x04hash\x18\x02 \x01(\x0c\x12\x0f\n\x07network\x18\x03 \x01(\t\"5\n\x04Iban\x12\x0e\n\x06masked\x18\x01 \x01(\x0c\x12\x0c\n\x04hash\x18\x02 \x01(\x0c\x12\x0f\n\x07\x63ountry\x18\x03 \x01(\t\"q\n\tHashCount\x12\x11\n\talgorithm\x18\x01 \x01(\t\x12,\n\nconfidence\x18\x02 \x01(\x0e\x32\x18.metadata.HashConfidence\x12\r\n\x05\x63ount\x18\x03 \x01(\x04\x12\x14\n\x0c\x61lternatives\x18\x04 \x03(\t\"o\n\x06Secret\x12\x0c\n\x04rule\x18\x01 \x01(\t\x12\x0f\n\x07preview\x18\x02 \x01(\x0c\x12\x0c\n\x04hash\x18\x03 \x01(\x0c\x12\x0f\n\x07\x65ntropy\x18\x04 \x01(\x01\x12\x12\n\njwt_issuer\x18\x05 \x01(\t\x12\x13\n\x0bjwt_subject\x18\x06 \x01(\t\"\x89\x01\n\nCredential\x12\r\n\x05\x65mail\x18\x01 \x01(\x0c\x12-\n\rpassword_kind\x18\x02 \x01(\x0e\x32\x16.metadata.PasswordKind\x12\x15\n\rpassword_hash\x18\x03 \x01(\x0c\x12\x16\n\x0ehash_algorithm\x18\x04 \x01(\t\x12\x0e\n\x06record\x18\x05 \x01(\x04\"t\n\rSourcedEntity\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c\x12&\n\x06origin\x18\x03 \x01(\x0e\x32\x16.metadata.EntityOrigin\x12\x0e\n\x06source\x18\x04 \x01(\t\x12\x0e\n\x06record\x18\x05 \x01(\x04\"J\n\x07\x41\x63\x63ount\x12\x10\n\x08username\x18\x01 \x01(\x0c\x12\r\n\x05\x65mail\x18\x02 \x01(\x0c\x12\x0e\n\x06source\x18\x03 \x01(\t\x12\x0e\n\x06record\x18\x04 \x01(\x04\"j\n\x06IpInfo\x12\n\n\x02ip\x18\x01 \x01(\x0c\x12 \n\x05\x63lass\x18\x02 \x01(\x0e\x32\x11.metadata.IpClass\x12\x0f\n\x07\x63ountry\x18\x03 \x01(\t\x12\x0b\n\x03\x61sn\x18\x04 \x01(\r\x12\x14\n\x0corganization\x18\x05 \x01(\t\"S\n\tEmailInfo\x12\r\n\x05\x65mail\x18\x01 \x01(\x0c\x12)\n\x08\x63\x61tegory\x18\x02 \x01(\x0e\x32\x17.metadata.EmailCategory\x12\x0c\n\x04role\x18\x03 \x01(\x08\"m\n\x06\x43ookie\x12\x0e\n\x06\x64omain\x18\x01 \x01(\x0c\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06\x65xpiry\x18\x03 \x01(\x04\x12\x0e\n\x06secure\x18\x04 \x01(\x08\x12\x11\n\thttp_only\x18\x05 \x01(\x08\x12\x12\n\nvalue_hash\x18\x06 \x01(\x0c\"<\n\rAutofillField\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x0e\n\x06record\x18\x03 \x01(\x04\"`\n\x10\x42rowserArtifacts\x12!\n\x07\x63ookies\x18\x01 \x03(\x0b\x32\x10.metadata.Cookie\x12)\n\x08\x61utofill\x18\x02 \x03(\x0b\x32\x17.metadata.AutofillField\"*\n\x0bSchemaField\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\r\n\x05\x63ount\x18\x02 \x01(\x04\")\n\nNamedCount\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\r\n\x05\x63ount\x18\x02 \x01(\x04\"\xa4\x02\n\tPartStats\x12\r\n\x05lines\x18\x01 \x01(\x04\x12\r\n\x05\x62ytes\x18\x02 \x01(\x04\x12\x13\n\x0b\x65mpty_lines\x18\x03 \x01(\x04\x12\x14\n\x0c\x62inary_lines\x18\x04 \x01(\x04\x12\x14\n\x0clongest_line\x18\x05 \x01(\x04\x12\x11\n\tdelimiter\x18\x06 \x01(\t\x12%\n\x07matches\x18\x07 \x03(\x0b\x32\x14.metadata.NamedCount\x12\x0f\n\x07records\x18\x08 \x01(\x04\x12\x15\n\rearliest_date\x18\t \x01(\x04\x12\x13\n\x0blatest_date\x18\n \x01(\x04\x12&\n\x08\x66iltered\x18\x0b \x03(\x0b\x32\x14.metadata.NamedCount\x12\x19\n\x11invalid_documents\x18\x0c \x01(\x04\"\x86\x01\n\x10\x44irectorySummary\x12\r\n\x05parts\x18\x01 \x01(\x04\x12\x14\n\x0c\x66\x61iled_parts\x18\x02 \x01(\x04\x12#\n\x06totals\x18\x03 \x01(\x0b\x32\x13.metadata.PartStats\x12(\n\ndelimiters\x18\x04 \x03(\x0b\x32\x14.metadata.NamedCount\">\n\x07Privacy\x12#\n\x04mode\x18\x01 \x01(\x0e\x32\x15.metadata.PrivacyMode\x12\x0e\n\x06key_id\x18\x02 \x01(\t\"\xd2\x04\n\x08Metadata\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06\x65mails\x18\x02 \x03(\x0c\x12\x0b\n\x03ips\x18\x03 \x03(\x0c\x12\x0f\n\x07\x64omains\x18\x04 \x03(\x0c\x12$\n\x05\x63\x61rds\x18\x05 \x03(\x0b\x32\x15.metadata.PaymentCard\x12\x1d\n\x05ibans\x18\x06 \x03(\x0b\x32\x0e.metadata.Iban\x12#\n\x06hashes\x18\x07 \x03(\x0b\x32\x13.metadata.HashCount\x12!\n\x07secrets\x18\x08 \x03(\x0b\x32\x10.metadata.Secret\x12)\n\x0b\x63redentials\x18\t \x03(\x0b\x32\x14.metadata.Credential\x12\x31\n\x10sourced_entities\x18\n \x03(\x0b\x32\x17.metadata.SourcedEntity\x12%\n\x06schema\x18\x0b \x03(\x0b\x32\x15.metadata.SchemaField\x12\x11\n\tpart_hash\x18\x0c \x01(\x04\x12\x19\n\x11\x65xtractor_version\x18\r \x01(\r\x12\"\n\x05stats\x18\x0e \x01(\x0b\x32\x13.metadata.PartStats\x12\x35\n\x11\x62rowser_artifacts\x18\x0f \x01(\x0b\x32\x1a.metadata.BrowserArtifacts\x12#\n\x08\x61\x63\x63ounts\x18\x10 \x03(\x0b\x32\x11.metadata.Account\x12\"\n\x08ip_infos\x18\x11 \x03(\x0b\x32\x10.metadata.IpInfo\x12(\n\x0b\x65mail_infos\x18\x12 \x03(\x0b\x32\x13.metadata.EmailInfo\"\x9f\x01\n\x0cMetadataList\x12!\n\x05items\x18\x01 \x03(\x0b\x32\x12.metadata.Metadata\x12+\n\x07summary\x18\x02 \x01(\x0b\x32\x1a.metadata.DirectorySummary\x12\"\n\x07privacy\x18\x03 \x01(\x0b\x32\x11.metadata.Privacy\x12\x1b\n\x13options_fingerprint\x18\x04 \x01(\t*h\n\x0eHashConfidence\x12\x1b\n\x17HASH_CONFIDENCE_CERTAIN\x10\x00\x12\x1a\n\x16HASH_CONFIDENCE_LIKELY\x10\x01\x12\x1d\n\x19HASH_CONFIDENCE_AMBIGUOUS\x10\x02*^\n\x0cPasswordKind\x12\x1b\n\x17PASSWORD_KIND_PLAINTEXT\x10\x00\x12\x18\n\x14PASSWORD_KIND_HASHED\x10\x01\x12\x17\n\x13PASSWORD_KIND_EMPTY\x10\x02*\x90\x02\n\x0c\x45ntityOrigin\x12\x16\n\x12\x45NTITY_ORIGIN_TEXT\x10\x00\x12\x1c\n\x18\x45NTITY_ORIGIN_CSV_COLUMN\x10\x01\x12\x1c\n\x18\x45NTITY_ORIGIN_JSON_FIELD\x10\x02\x12\x18\n\x14\x45NTITY_ORIGIN_RECORD\x10\x03\x12\x19\n\x15\x45NTITY_ORIGIN_ENCODED\x10\x04\x12\x1d\n\x19\x45NTITY_ORIGIN_MAIL_HEADER\x10\x05\x12\"\n\x1e\x45NTITY_ORIGIN_MARKUP_ATTRIBUTE\x10\x06\x12\x1a\n\x16\x45NTITY_ORIGIN_AUTOFILL\x10\x07\x12\x18\n\x14\x45NTITY_ORIGIN_HANDLE\x10\x08*\xc3\x01\n\x07IpClass\x12\x13\n\x0fIP_CLASS_PUBLIC\x10\x00\x12\x14\n\x10IP_CLASS_PRIVATE\x10\x01\x12\x12\n\x0eIP_CLASS_CGNAT\x10\x02\x12\x15\n\x11IP_CLASS_RESERVED\x10\x03\x12\x15\n\x11IP_CLASS_LOOPBACK\x10\x04\x12\x17\n\x13IP_CLASS_LINK_LOCAL\x10\x05\x12\x16\n\x12IP_CLASS_MULTICAST\x10\x06\x12\x1a\n\x16IP_CLASS_DOCUMENTATION\x10\x07*i\n\rEmailCategory\x12\x1c\n\x18\x45MAIL_CATEGORY_CORPORATE\x10\x00\x12\x1b\n\x17\x45MAIL_CATEGORY_FREEMAIL\x10\x01\x12\x1d\n\x19\x45MAIL_CATEGORY_DISPOSABLE\x10\x02*@\n\x0bPrivacyMode\x12\x1a\n\x16PRIVACY_MODE_PLAINTEXT\x10\x00\x12\x15\n\x11PRIVACY_MODE_HMAC\x10\x01\x62\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'proto.metadata.metadata_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   DESCRIPTOR._loaded_options = None
-  _globals['_METADATA']._serialized_start=43
-  _globals['_METADATA']._serialized_end=111
-  _globals['_METADATALIST']._serialized_start=113
-  _globals['_METADATALIST']._serialized_end=162
+  _globals['_HASHCONFIDENCE']._serialized_start=2528
+  _globals['_HASHCONFIDENCE']._serialized_end=2632
+  _globals['_PASSWORDKIND']._serialized_start=2634
+  _globals['_PASSWORDKIND']._serialized_end=2728
+  _globals['_ENTITYORIGIN']._serialized_start=2731
+  _globals['_ENTITYORIGIN']._serialized_end=3003
+  _globals['_IPCLASS']._serialized_start=3006
+  _globals['_IPCLASS']._serialized_end=3201
+  _globals['_EMAILCATEGORY']._serialized_start=3203
+  _globals['_EMAILCATEGORY']._serialized_end=3308
+  _globals['_PRIVACYMODE']._serialized_start=3310
+  _globals['_PRIVACYMODE']._serialized_end=3374
+  _globals['_PAYMENTCARD']._serialized_start=43
+  _globals['_PAYMENTCARD']._serialized_end=103
+  _globals['_IBAN']._serialized_start=105
+  _globals['_IBAN']._serialized_end=158
+  _globals['_HASHCOUNT']._serialized_start=160
+  _globals['_HASHCOUNT']._serialized_end=273
+  _globals['_SECRET']._serialized_start=275
+  _globals['_SECRET']._serialized_end=386
+  _globals['_CREDENTIAL']._serialized_start=389
+  _globals['_CREDENTIAL']._serialized_end=526
+  _globals['_SOURCEDENTITY']._serialized_start=528
+  _globals['_SOURCEDENTITY']._serialized_end=644
+  _globals['_ACCOUNT']._serialized_start=646
+  _globals['_ACCOUNT']._serialized_end=720
+  _globals['_IPINFO']._serialized_start=722
+  _globals['_IPINFO']._serialized_end=828
+  _globals['_EMAILINFO']._serialized_start=830
+  _globals['_EMAILINFO']._serialized_end=913
+  _globals['_COOKIE']._serialized_start=915
+  _globals['_COOKIE']._serialized_end=1024
+  _globals['_AUTOFILLFIELD']._serialized_start=1026
+  _globals['_AUTOFILLFIELD']._serialized_end=1086
+  _globals['_BROWSERARTIFACTS']._serialized_start=1088
+  _globals['_BROWSERARTIFACTS']._serialized_end=1184
+  _globals['_SCHEMAFIELD']._serialized_start=1186
+  _globals['_SCHEMAFIELD']._serialized_end=1228
+  _globals['_NAMEDCOUNT']._serialized_start=1230
+  _globals['_NAMEDCOUNT']._serialized_end=1271
+  _globals['_PARTSTATS']._serialized_start=1274
+  _globals['_PARTSTATS']._serialized_end=1566
+  _globals['_DIRECTORYSUMMARY']._serialized_start=1569
+  _globals['_DIRECTORYSUMMARY']._serialized_end=1703
+  _globals['_PRIVACY']._serialized_start=1705
+  _globals['_PRIVACY']._serialized_end=1767
+  _globals['_METADATA']._serialized_start=1770
+  _globals['_METADATA']._serialized_end=2364
+  _globals['_METADATALIST']._serialized_start=2367
+  _globals['_METADATALIST']._serialized_end=2526
 # @@protoc_insertion_point(module_scope)
@@ -1,4 +1,5 @@
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
 from collections.abc import Iterable as _Iterable, Mapping as _Mapping
@@ -6,20 +7,338 @@ from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class HashConfidence(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    HASH_CONFIDENCE_CERTAIN: _ClassVar[HashConfidence]
+    HASH_CONFIDENCE_LIKELY: _ClassVar[HashConfidence]
+    HASH_CONFIDENCE_AMBIGUOUS: _ClassVar[HashConfidence]
+
+class PasswordKind(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    PASSWORD_KIND_PLAINTEXT: _ClassVar[PasswordKind]
+    PASSWORD_KIND_HASHED: _ClassVar[PasswordKind]
+    PASSWORD_KIND_EMPTY: _ClassVar[PasswordKind]
+
+class EntityOrigin(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    ENTITY_ORIGIN_TEXT: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_CSV_COLUMN: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_JSON_FIELD: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_RECORD: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_ENCODED: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_MAIL_HEADER: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_MARKUP_ATTRIBUTE: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_AUTOFILL: _ClassVar[EntityOrigin]
+    ENTITY_ORIGIN_HANDLE: _ClassVar[EntityOrigin]
+
+class IpClass(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    IP_CLASS_PUBLIC: _ClassVar[IpClass]
+    IP_CLASS_PRIVATE: _ClassVar[IpClass]
+    IP_CLASS_CGNAT: _ClassVar[IpClass]
+    IP_CLASS_RESERVED: _ClassVar[IpClass]
+    IP_CLASS_LOOPBACK: _ClassVar[IpClass]
+    IP_CLASS_LINK_LOCAL: _ClassVar[IpClass]
+    IP_CLASS_MULTICAST: _ClassVar[IpClass]
+    IP_CLASS_DOCUMENTATION: _ClassVar[IpClass]
+
+class EmailCategory(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    EMAIL_CATEGORY_CORPORATE: _ClassVar[EmailCategory]
+    EMAIL_CATEGORY_FREEMAIL: _ClassVar[EmailCategory]
+    EMAIL_CATEGORY_DISPOSABLE: _ClassVar[EmailCategory]
+
+class PrivacyMode(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    PRIVACY_MODE_PLAINTEXT: _ClassVar[PrivacyMode]
+    PRIVACY_MODE_HMAC: _ClassVar[PrivacyMode]
+HASH_CONFIDENCE_CERTAIN: HashConfidence
+HASH_CONFIDENCE_LIKELY: HashConfidence
+HASH_CONFIDENCE_AMBIGUOUS: HashConfidence
+PASSWORD_KIND_PLAINTEXT: PasswordKind
+PASSWORD_KIND_HASHED: PasswordKind
+PASSWORD_KIND_EMPTY: PasswordKind
+ENTITY_ORIGIN_TEXT: EntityOrigin
+ENTITY_ORIGIN_CSV_COLUMN: EntityOrigin
+ENTITY_ORIGIN_JSON_FIELD: EntityOrigin
+ENTITY_ORIGIN_RECORD: EntityOrigin
+ENTITY_ORIGIN_ENCODED: EntityOrigin
+ENTITY_ORIGIN_MAIL_HEADER: EntityOrigin
+ENTITY_ORIGIN_MARKUP_ATTRIBUTE: EntityOrigin
+ENTITY_ORIGIN_AUTOFILL: EntityOrigin
+ENTITY_ORIGIN_HANDLE: EntityOrigin
+IP_CLASS_PUBLIC: IpClass
+IP_CLASS_PRIVATE: IpClass
+IP_CLASS_CGNAT: IpClass
+IP_CLASS_RESERVED: IpClass
+IP_CLASS_LOOPBACK: IpClass
+IP_CLASS_LINK_LOCAL: IpClass
+IP_CLASS_MULTICAST: IpClass
+IP_CLASS_DOCUMENTATION: IpClass
+EMAIL_CATEGORY_CORPORATE: EmailCategory
+EMAIL_CATEGORY_FREEMAIL: EmailCategory
+EMAIL_CATEGORY_DISPOSABLE: EmailCategory
+PRIVACY_MODE_PLAINTEXT: PrivacyMode
+PRIVACY_MODE_HMAC: PrivacyMode
+
+class PaymentCard(_message.Message):
+    __slots__ = ("masked", "hash", "network")
+    MASKED_FIELD_NUMBER: _ClassVar[int]
+    HASH_FIELD_NUMBER: _ClassVar[int]
+    NETWORK_FIELD_NUMBER: _ClassVar[int]
+    masked: bytes
+    hash: bytes
+    network: str
+    def __init__(self, masked: _Optional[bytes] = ..., hash: _Optional[bytes] = ..., network: _Optional[str] = ...) -> None: ...
+
+class Iban(_message.Message):
+    __slots__ = ("masked", "hash", "country")
+    MASKED_FIELD_NUMBER: _ClassVar[int]
+    HASH_FIELD_NUMBER: _ClassVar[int]
+    COUNTRY_FIELD_NUMBER: _ClassVar[int]
+    masked: bytes
+    hash: bytes
+    country: str
+    def __init__(self, masked: _Optional[bytes] = ..., hash: _Optional[bytes] = ..., country: _Optional[str] = ...) -> None: ...
+
+class HashCount(_message.Message):
+    __slots__ = ("algorithm", "confidence", "count", "alternatives")
+    ALGORITHM_FIELD_NUMBER: _ClassVar[int]
+    CONFIDENCE_FIELD_NUMBER: _ClassVar[int]
+    COUNT_FIELD_NUMBER: _ClassVar[int]
+    ALTERNATIVES_FIELD_NUMBER: _ClassVar[int]
+    algorithm: str
+    confidence: HashConfidence
+    count: int
+    alternatives: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, algorithm: _Optional[str] = ..., confidence: _Optional[_Union[HashConfidence, str]] = ..., count: _Optional[int] = ..., alternatives: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class Secret(_message.Message):
+    __slots__ = ("rule", "preview", "hash", "entropy", "jwt_issuer", "jwt_subject")
+    RULE_FIELD_NUMBER: _ClassVar[int]
+    PREVIEW_FIELD_NUMBER: _ClassVar[int]
+    HASH_FIELD_NUMBER: _ClassVar[int]
+    ENTROPY_FIELD_NUMBER: _ClassVar[int]
+    JWT_ISSUER_FIELD_NUMBER: _ClassVar[int]
+    JWT_SUBJECT_FIELD_NUMBER: _ClassVar[int]
+    rule: str
+    preview: bytes
+    hash: bytes
+    entropy: float
+    jwt_issuer: str
+    jwt_subject: str
+    def __init__(self, rule: _Optional[str] = ..., preview: _Optional[bytes] = ..., hash: _Optional[bytes] = ..., entropy: _Optional[float] = ..., jwt_issuer: _Optional[str] = ..., jwt_subject: _Optional[str] = ...) -> None: ...
+
+class Credential(_message.Message):
+    __slots__ = ("email", "password_kind", "password_hash", "hash_algorithm", "record")
+    EMAIL_FIELD_NUMBER: _ClassVar[int]
+    PASSWORD_KIND_FIELD_NUMBER: _ClassVar[int]
+    PASSWORD_HASH_FIELD_NUMBER: _ClassVar[int]
+    HASH_ALGORITHM_FIELD_NUMBER: _ClassVar[int]
+    RECORD_FIELD_NUMBER: _ClassVar[int]
+    email: bytes
+    password_kind: PasswordKind
+    password_hash: bytes
+    hash_algorithm: str
+    record: int
+    def __init__(self, email: _Optional[bytes] = ..., password_kind: _Optional[_Union[PasswordKind, str]] = ..., password_hash: _Optional[bytes] = ..., hash_algorithm: _Optional[str] = ..., record: _Optional[int] = ...) -> None: ...
+
+class SourcedEntity(_message.Message):
+    __slots__ = ("type", "value", "origin", "source", "record")
+    TYPE_FIELD_NUMBER: _ClassVar[int]
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    ORIGIN_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_FIELD_NUMBER: _ClassVar[int]
+    RECORD_FIELD_NUMBER: _ClassVar[int]
+    type: str
+    value: bytes
+    origin: EntityOrigin
+    source: str
+    record: int
+    def __init__(self, type: _Optional[str] = ..., value: _Optional[bytes] = ..., origin: _Optional[_Union[EntityOrigin, str]] = ..., source: _Optional[str] = ..., record: _Optional[int] = ...) -> None: ...
+
+class Account(_message.Message):
+    __slots__ = ("username", "email", "source", "record")
+    USERNAME_FIELD_NUMBER: _ClassVar[int]
+    EMAIL_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_FIELD_NUMBER: _ClassVar[int]
+    RECORD_FIELD_NUMBER: _ClassVar[int]
+    username: bytes
+    email: bytes
+    source: str
+    record: int
+    def __init__(self, username: _Optional[bytes] = ..., email: _Optional[bytes] = ..., source: _Optional[str] = ..., record: _Optional[int] = ...) -> None: ...
+
+class IpInfo(_message.Message):
+    __slots__ = ("ip", "class", "country", "asn", "organization")
+    IP_FIELD_NUMBER: _ClassVar[int]
+    CLASS_FIELD_NUMBER: _ClassVar[int]
+    COUNTRY_FIELD_NUMBER: _ClassVar[int]
+    ASN_FIELD_NUMBER: _ClassVar[int]
+    ORGANIZATION_FIELD_NUMBER: _ClassVar[int]
+    ip: bytes
+    class: IpClass
+    country: str
+    asn: int
+    organization: str
+    def __init__(self, ip: _Optional[bytes] = ..., class: _Optional[_Union[IpClass, str]] = ..., country: _Optional[str] = ..., asn: _Optional[int] = ..., organization: _Optional[str] = ...) -> None: ...
+
+class EmailInfo(_message.Message):
+    __slots__ = ("email", "category", "role")
+    EMAIL_FIELD_NUMBER: _ClassVar[int]
+    CATEGORY_FIELD_NUMBER: _ClassVar[int]
+    ROLE_FIELD_NUMBER: _ClassVar[int]
+    email: bytes
+    category: EmailCategory
+    role: bool
+    def __init__(self, email: _Optional[bytes] = ..., category: _Optional[_Union[EmailCategory, str]] = ..., role: bool = ...) -> None: ...
+
+class Cookie(_message.Message):
+    __slots__ = ("domain", "name", "expiry", "secure", "http_only", "value_hash")
+    DOMAIN_FIELD_NUMBER: _ClassVar[int]
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    EXPIRY_FIELD_NUMBER: _ClassVar[int]
+    SECURE_FIELD_NUMBER: _ClassVar[int]
+    HTTP_ONLY_FIELD_NUMBER: _ClassVar[int]
+    VALUE_HASH_FIELD_NUMBER: _ClassVar[int]
+    domain: bytes
+    name: str
+    expiry: int
+    secure: bool
+    http_only: bool
+    value_hash: bytes
+    def __init__(self, domain: _Optional[bytes] = ..., name: _Optional[str] = ..., expiry: _Optional[int] = ..., secure: bool = ..., http_only: bool = ..., value_hash: _Optional[bytes] = ...) -> None: ...
+
+class AutofillField(_message.Message):
+    __slots__ = ("name", "value", "record")
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    RECORD_FIELD_NUMBER: _ClassVar[int]
+    name: str
+    value: bytes
+    record: int
+    def __init__(self, name: _Optional[str] = ..., value: _Optional[bytes] = ..., record: _Optional[int] = ...) -> None: ...
+
+class BrowserArtifacts(_message.Message):
+    __slots__ = ("cookies", "autofill")
+    COOKIES_FIELD_NUMBER: _ClassVar[int]
+    AUTOFILL_FIELD_NUMBER: _ClassVar[int]
+    cookies: _containers.RepeatedCompositeFieldContainer[Cookie]
+    autofill: _containers.RepeatedCompositeFieldContainer[AutofillField]
+    def __init__(self, cookies: _Optional[_Iterable[_Union[Cookie, _Mapping]]] = ..., autofill: _Optional[_Iterable[_Union[AutofillField, _Mapping]]] = ...) -> None: ...
+
+class SchemaField(_message.Message):
+    __slots__ = ("path", "count")
+    PATH_FIELD_NUMBER: _ClassVar[int]
+    COUNT_FIELD_NUMBER: _ClassVar[int]
+    path: str
+    count: int
+    def __init__(self, path: _Optional[str] = ..., count: _Optional[int] = ...) -> None: ...
+
+class NamedCount(_message.Message):
+    __slots__ = ("name", "count")
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    COUNT_FIELD_NUMBER: _ClassVar[int]
+    name: str
+    count: int
+    def __init__(self, name: _Optional[str] = ..., count: _Optional[int] = ...) -> None: ...
+
+class PartStats(_message.Message):
+    __slots__ = ("lines", "bytes", "empty_lines", "binary_lines", "longest_line", "delimiter", "matches", "records", "earliest_date", "latest_date", "filtered", "invalid_documents")
+    LINES_FIELD_NUMBER: _ClassVar[int]
+    BYTES_FIELD_NUMBER: _ClassVar[int]
+    EMPTY_LINES_FIELD_NUMBER: _ClassVar[int]
+    BINARY_LINES_FIELD_NUMBER: _ClassVar[int]
+    LONGEST_LINE_FIELD_NUMBER: _ClassVar[int]
+    DELIMITER_FIELD_NUMBER: _ClassVar[int]
+    MATCHES_FIELD_NUMBER: _ClassVar[int]
+    RECORDS_FIELD_NUMBER: _ClassVar[int]
+    EARLIEST_DATE_FIELD_NUMBER: _ClassVar[int]
+    LATEST_DATE_FIELD_NUMBER: _ClassVar[int]
+    FILTERED_FIELD_NUMBER: _ClassVar[int]
+    INVALID_DOCUMENTS_FIELD_NUMBER: _ClassVar[int]
+    lines: int
+    bytes: int
+    empty_lines: int
+    binary_lines: int
+    longest_line: int
+    delimiter: str
+    matches: _containers.RepeatedCompositeFieldContainer[NamedCount]
+    records: int
+    earliest_date: int
+    latest_date: int
+    filtered: _containers.RepeatedCompositeFieldContainer[NamedCount]
+    invalid_documents: int
+    def __init__(self, lines: _Optional[int] = ..., bytes: _Optional[int] = ..., empty_lines: _Optional[int] = ..., binary_lines: _Optional[int] = ..., longest_line: _Optional[int] = ..., delimiter: _Optional[str] = ..., matches: _Optional[_Iterable[_Union[NamedCount, _Mapping]]] = ..., records: _Optional[int] = ..., earliest_date: _Optional[int] = ..., latest_date: _Optional[int] = ..., filtered: _Optional[_Iterable[_Union[NamedCount, _Mapping]]] = ..., invalid_documents: _Optional[int] = ...) -> None: ...
+
+class DirectorySummary(_message.Message):
+    __slots__ = ("parts", "failed_parts", "totals", "delimiters")
+    PARTS_FIELD_NUMBER: _ClassVar[int]
+    FAILED_PARTS_FIELD_NUMBER: _ClassVar[int]
+    TOTALS_FIELD_NUMBER: _ClassVar[int]
+    DELIMITERS_FIELD_NUMBER: _ClassVar[int]
+    parts: int
+    failed_parts: int
+    totals: PartStats
+    delimiters: _containers.RepeatedCompositeFieldContainer[NamedCount]
+    def __init__(self, parts: _Optional[int] = ..., failed_parts: _Optional[int] = ..., totals: _Optional[_Union[PartStats, _Mapping]] = ..., delimiters: _Optional[_Iterable[_Union[NamedCount, _Mapping]]] = ...) -> None: ...
+
+class Privacy(_message.Message):
+    __slots__ = ("mode", "key_id")
+    MODE_FIELD_NUMBER: _ClassVar[int]
+    KEY_ID_FIELD_NUMBER: _ClassVar[int]
+    mode: PrivacyMode
+    key_id: str
+    def __init__(self, mode: _Optional[_Union[PrivacyMode, str]] = ..., key_id: _Optional[str] = ...) -> None: ...
+
 class Metadata(_message.Message):
-    __slots__ = ("id", "emails", "ips", "domains")
+    __slots__ = ("id", "emails", "ips", "domains", "cards", "ibans", "hashes", "secrets", "credentials", "sourced_entities", "schema", "part_hash", "extractor_version", "stats", "browser_artifacts", "accounts", "ip_infos", "email_infos")
     ID_FIELD_NUMBER: _ClassVar[int]
     EMAILS_FIELD_NUMBER: _ClassVar[int]
     IPS_FIELD_NUMBER: _ClassVar[int]
     DOMAINS_FIELD_NUMBER: _ClassVar[int]
+    CARDS_FIELD_NUMBER: _ClassVar[int]
+    IBANS_FIELD_NUMBER: _ClassVar[int]
+    HASHES_FIELD_NUMBER: _ClassVar[int]
+    SECRETS_FIELD_NUMBER: _ClassVar[int]
+    CREDENTIALS_FIELD_NUMBER: _ClassVar[int]
+    SOURCED_ENTITIES_FIELD_NUMBER: _ClassVar[int]
+    SCHEMA_FIELD_NUMBER: _ClassVar[int]
+    PART_HASH_FIELD_NUMBER: _ClassVar[int]
+    EXTRACTOR_VERSION_FIELD_NUMBER: _ClassVar[int]
+    STATS_FIELD_NUMBER: _ClassVar[int]
+    BROWSER_ARTIFACTS_FIELD_NUMBER: _ClassVar[int]
+    ACCOUNTS_FIELD_NUMBER: _ClassVar[int]
+    IP_INFOS_FIELD_NUMBER: _ClassVar[int]
+    EMAIL_INFOS_FIELD_NUMBER: _ClassVar[int]
     id: str
     emails: _containers.RepeatedScalarFieldContainer[bytes]
     ips: _containers.RepeatedScalarFieldContainer[bytes]
     domains: _containers.RepeatedScalarFieldContainer[bytes]
-    def __init__(self, id: _Optional[str] = ..., emails: _Optional[_Iterable[bytes]] = ..., ips: _Optional[_Iterable[bytes]] = ..., domains: _Optional[_Iterable[bytes]] = ...) -> None: ...
+    cards: _containers.RepeatedCompositeFieldContainer[PaymentCard]
+    ibans: _containers.RepeatedCompositeFieldContainer[Iban]
+    hashes: _containers.RepeatedCompositeFieldContainer[HashCount]
+    secrets: _containers.RepeatedCompositeFieldContainer[Secret]
+    credentials: _containers.RepeatedCompositeFieldContainer[Credential]
+    sourced_entities: _containers.RepeatedCompositeFieldContainer[SourcedEntity]
+    schema: _containers.RepeatedCompositeFieldContainer[SchemaField]
+    part_hash: int
+    extractor_version: int
+    stats: PartStats
+    browser_artifacts: BrowserArtifacts
+    accounts: _containers.RepeatedCompositeFieldContainer[Account]
+    ip_infos: _containers.RepeatedCompositeFieldContainer[IpInfo]
+    email_infos: _containers.RepeatedCompositeFieldContainer[EmailInfo]
+    def __init__(self, id: _Optional[str] = ..., emails: _Optional[_Iterable[bytes]] = ..., ips: _Optional[_Iterable[bytes]] = ..., domains: _Optional[_Iterable[bytes]] = ..., cards: _Optional[_Iterable[_Union[PaymentCard, _Mapping]]] = ..., ibans: _Optional[_Iterable[_Union[Iban, _Mapping]]] = ..., hashes: _Optional[_Iterable[_Union[HashCount, _Mapping]]] = ..., secrets: _Optional[_Iterable[_Union[Secret, _Mapping]]] = ..., credentials: _Optional[_Iterable[_Union[Credential, _Mapping]]] = ..., sourced_entities: _Optional[_Iterable[_Union[SourcedEntity, _Mapping]]] = ..., schema: _Optional[_Iterable[_Union[SchemaField, _Mapping]]] = ..., part_hash: _Optional[int] = ..., extractor_version: _Optional[int] = ..., stats: _Optional[_Union[PartStats, _Mapping]] = ..., browser_artifacts: _Optional[_Union[BrowserArtifacts, _Mapping]] = ..., accounts: _Optional[_Iterable[_Union[Account, _Mapping]]] = ..., ip_infos: _Optional[_Iterable[_Union[IpInfo, _Mapping]]] = ..., email_infos: _Optional[_Iterable[_Union[EmailInfo, _Mapping]]] = ...) -> None: ...
 
 class MetadataList(_message.Message):
-    __slots__ = ("items",)
+    __slots__ = ("items", "summary", "privacy", "options_fingerprint")
     ITEMS_FIELD_NUMBER: _ClassVar[int]
+    SUMMARY_FIELD_NUMBER: _ClassVar[int]
+    PRIVACY_FIELD_NUMBER: _ClassVar[int]
+    OPTIONS_FINGERPRINT_FIELD_NUMBER: _ClassVar[int]
     items: _containers.RepeatedCompositeFieldContainer[Metadata]
-    def __init__(self, items: _Optional[_Iterable[_Union[Metadata, _Mapping]]] = ...) -> None: ...
+    summary: DirectorySummary
+    privacy: Privacy
+    options_fingerprint: str
+    def __init__(self, items: _Optional[_Iterable[_Union[Metadata, _Mapping]]] = ..., summary: _Optional[_Union[DirectorySummary, _Mapping]] = ..., privacy: _Optional[_Union[Privacy, _Mapping]] = ..., options_fingerprint: _Optional[str] = ...) -> None: ...
@@ -0,0 +1,29 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	"log"
+	"os"
+)
+
+// Prints what to search for in metadata extracted with --privacy-mode hmac, e.g.
+// HMAC_KEY=... go run scripts/misc/privacy_hash.go email John.Doe@Example.com
+func main() {
+	if len(os.Args) < 3 {
+		log.Fatalf("Usage: HMAC_KEY=<key> %s <email|ip|domain|phone|username|...> <value>...", os.Args[0])
+	}
+
+	key := []byte(os.Getenv("HMAC_KEY"))
+	if len(key) == 0 {
+		log.Fatalf("HMAC_KEY is not set")
+	}
+
+	fmt.Printf("Key id: %s\n", utils.KeyId(key))
+
+	for _, value := range os.Args[2:] {
+		fmt.Printf("%s\t%s\n", string(utils.EntityHash(key, os.Args[1], value)), utils.NormalizeEntity(os.Args[1], value))
+	}
+}
@@ -1,44 +1,195 @@
+//go:build ignore
+
 package main
 
 import (
 	"fmt"
+	"github.com/Rom1-J/preprocessor/app/extract/logic/generator"
+	"github.com/Rom1-J/preprocessor/constants"
+	"github.com/Rom1-J/preprocessor/pkg/utils"
+	infoproto "github.com/Rom1-J/preprocessor/proto/info"
 	metadataproto "github.com/Rom1-J/preprocessor/proto/metadata"
+	"io"
 	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"google.golang.org/protobuf/proto"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		log.Fatalf("Usage: %s <path_to_protobuf_file>", os.Args[0])
+		log.Fatalf("Usage: %s <path_to_protobuf_file> [path_to_info_file]", os.Args[0])
 	}
 
-	filePath := os.Args[1]
-	data, err := os.ReadFile(filePath)
+	// _metadata.pb is streamed item by item, see utils.DelimitedWriter
+	reader, err := utils.OpenDelimitedReader(os.Args[1])
 	if err != nil {
 		log.Fatalf("Failed to read file: %v", err)
 	}
-
-	metadata := &metadataproto.MetadataList{}
-	err = proto.Unmarshal(data, metadata)
-	if err != nil {
-		log.Fatalf("Failed to unmarshal protobuf data: %v", err)
-	}
+	defer reader.Close()
 
 	var (
 		files   int
 		emails  int
 		domains int
 		ips     int
+		cards   int
+		ibans   int
+		hashes  uint64
+		secrets int
+		creds   int
+		sourced int
+
+		ids     []string
+		summary *metadataproto.DirectorySummary
 	)
 
-	for _, item := range metadata.Items {
+	for {
+		field, data, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Failed to read protobuf stream: %v", err)
+		}
+		if field == constants.MetadataListSummaryField {
+			summary = &metadataproto.DirectorySummary{}
+			if err := proto.Unmarshal(data, summary); err != nil {
+				log.Fatalf("Failed to unmarshal summary: %v", err)
+			}
+			continue
+		}
+		if field != constants.MetadataListItemsField {
+			continue
+		}
+
+		item := &metadataproto.Metadata{}
+		if err := proto.Unmarshal(data, item); err != nil {
+			log.Fatalf("Failed to unmarshal protobuf data: %v", err)
+		}
+
 		files++
+		ids = append(ids, item.Id)
 		emails += len(item.Emails)
 		domains += len(item.Domains)
 		ips += len(item.Ips)
+		cards += len(item.Cards)
+		ibans += len(item.Ibans)
+		for _, hash := range item.Hashes {
+			hashes += hash.Count
+		}
+		secrets += len(item.Secrets)
+		creds += len(item.Credentials)
+		sourced += len(item.SourcedEntities)
+	}
+
+	fmt.Printf(
+		"Files: %d | emails: %d | domains: %d | ips: %d | cards: %d | ibans: %d | hashes: %d | secrets: %d | credentials: %d | sourced: %d\n",
+		files, emails, domains, ips, cards, ibans, hashes, secrets, creds, sourced,
+	)
+
+	if summary != nil && summary.Totals != nil {
+		totals := summary.Totals
+
+		var matched uint64
+		for _, match := range totals.Matches {
+			matched += match.Count
+		}
+
+		fmt.Printf(
+			"Summary: %d parts (%d failed) | lines: %d | bytes: %d | empty: %d | binary: %d | longest: %d | records: %d | matches: %d (%.4f per line)\n",
+			summary.Parts, summary.FailedParts, totals.Lines, totals.Bytes, totals.EmptyLines, totals.BinaryLines,
+			totals.LongestLine, totals.Records, matched, float64(matched)/float64(max(totals.Lines, 1)),
+		)
+		fmt.Printf("  dates: %s\n", generator.FormatDateRange(totals.EarliestDate, totals.LatestDate))
+		if totals.InvalidDocuments > 0 {
+			fmt.Printf("  invalid JSON documents: %d\n", totals.InvalidDocuments)
+		}
+		for _, match := range totals.Matches {
+			fmt.Printf("  %s: %d\n", match.Name, match.Count)
+		}
+		for _, filtered := range totals.Filtered {
+			fmt.Printf("  filtered by %q: %d\n", filtered.Name, filtered.Count)
+		}
+		for _, delimiter := range summary.Delimiters {
+			fmt.Printf("  delimiter %q: %d parts\n", delimiter.Name, delimiter.Count)
+		}
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	//
+	// Check items against _info.pb: one item per readable part, in path order
+	//
+	infoFilePath := filepath.Join(filepath.Dir(os.Args[1]), "_info.pb")
+	if len(os.Args) > 2 {
+		infoFilePath = os.Args[2]
+	}
+
+	infoData, err := os.ReadFile(infoFilePath)
+	if err != nil {
+		fmt.Printf("No parts check, failed to read %s: %v\n", infoFilePath, err)
+		return
 	}
 
-	fmt.Println(fmt.Sprintf("Files: %d | emails: %d | domains: %d | ips: %d", files, emails, domains, ips))
+	metadataInfo := &infoproto.MetadataInfo{}
+	if err := proto.Unmarshal(infoData, metadataInfo); err != nil {
+		log.Fatalf("Failed to unmarshal protobuf data for %s: %v", infoFilePath, err)
+	}
+
+	paths := generator.RetrieveReadableFilePaths(metadataInfo)
+
+	var (
+		expected    []string
+		expectedSet = make(map[string]struct{}, len(paths))
+	)
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		expected = append(expected, paths[path].Id)
+		expectedSet[paths[path].Id] = struct{}{}
+	}
+
+	seen := make(map[string]int)
+	for _, id := range ids {
+		seen[id]++
+	}
+
+	var missing, duplicated, unexpected int
+	for _, id := range expected {
+		if seen[id] == 0 {
+			missing++
+		}
+	}
+	for id, count := range seen {
+		if count > 1 {
+			duplicated++
+		}
+		if _, ok := expectedSet[id]; !ok {
+			unexpected++
+		}
+	}
+
+	// parts skipped because their file is missing do not break the order of the others
+	ordered := true
+	position := 0
+	for _, id := range ids {
+		for position < len(expected) && expected[position] != id {
+			position++
+		}
+		if position == len(expected) {
+			ordered = false
+			break
+		}
+	}
+
+	fmt.Printf(
+		"Parts: %d | items: %d | missing: %d | duplicated: %d | unexpected: %d | ordered: %t\n",
+		len(expected), len(ids), missing, duplicated, unexpected, ordered,
+	)
+
+	if missing > 0 || duplicated > 0 || unexpected > 0 || !ordered {
+		os.Exit(1)
+	}
+	// <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 }